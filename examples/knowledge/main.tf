@@ -56,9 +56,15 @@ resource "openwebui_group" "researchers" {
 
 # Example 1: Technical Documentation Knowledge Base
 resource "openwebui_knowledge" "tech_docs" {
-  name           = "Technical Documentation"
-  description    = "Comprehensive technical documentation for our systems"
-  access_control = "private" # Restricted access
+  name        = "Technical Documentation"
+  description = "Comprehensive technical documentation for our systems"
+
+  # Restricted to the developers group
+  access_control = {
+    read = {
+      group_ids = [openwebui_group.developers.id]
+    }
+  }
 
   data = {
     category    = "technical"
@@ -76,9 +82,17 @@ resource "openwebui_knowledge" "tech_docs" {
 
 # Example 2: Research Papers Knowledge Base
 resource "openwebui_knowledge" "research_papers" {
-  name           = "Research Papers"
-  description    = "Collection of research papers and findings"
-  access_control = "private"
+  name        = "Research Papers"
+  description = "Collection of research papers and findings"
+
+  access_control = {
+    read = {
+      group_ids = [openwebui_group.researchers.id]
+    }
+    write = {
+      group_ids = [openwebui_group.researchers.id]
+    }
+  }
 
   data = {
     category        = "research"
@@ -92,10 +106,10 @@ resource "openwebui_knowledge" "research_papers" {
 }
 
 # Example 3: Public Documentation
+# access_control is unset, so the knowledge base is public
 resource "openwebui_knowledge" "public_docs" {
-  name           = "Public API Documentation"
-  description    = "Public-facing API documentation and guides"
-  access_control = "public"
+  name        = "Public API Documentation"
+  description = "Public-facing API documentation and guides"
 
   data = {
     category = "api-documentation"
@@ -109,9 +123,11 @@ resource "openwebui_knowledge" "public_docs" {
 
 # Example 4: Training Materials
 resource "openwebui_knowledge" "training" {
-  name           = "Employee Training Materials"
-  description    = "Internal training documentation and resources"
-  access_control = "private"
+  name        = "Employee Training Materials"
+  description = "Internal training documentation and resources"
+
+  # Private to the owner
+  access_control = {}
 
   data = {
     category   = "training"
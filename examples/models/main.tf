@@ -118,6 +118,14 @@ resource "openwebui_model" "research_assistant" {
     tags {
       name = "research"
     }
+
+    # Retrieve from the model documentation knowledge base
+    knowledge = [
+      {
+        id   = openwebui_knowledge.model_docs.id
+        name = openwebui_knowledge.model_docs.name
+      }
+    ]
   }
 
   access_control {
@@ -150,7 +158,7 @@ resource "openwebui_model" "code_reviewer" {
     temperature       = 0.2 # Low temperature for consistent code analysis
     top_p             = 0.8
     max_tokens        = 1500
-    frequency_penalty = 1 # Reduce repetitive suggestions
+    frequency_penalty = 0.5 # Reduce repetitive suggestions
   }
 
   meta {
@@ -0,0 +1,711 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/functions"
+	"terraform-provider-openwebui/internal/provider/client/models"
+	"terraform-provider-openwebui/internal/provider/client/tools"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &BundleResource{}
+var _ resource.ResourceWithModifyPlan = &BundleResource{}
+
+// bundleVolatileKeys are export fields that differ between instances and
+// exports without the item itself changing
+var bundleVolatileKeys = []string{"user_id", "created_at", "updated_at"}
+
+func NewToolsBundleResource() resource.Resource {
+	return &BundleResource{kind: "tool"}
+}
+
+func NewFunctionsBundleResource() resource.Resource {
+	return &BundleResource{kind: "function"}
+}
+
+func NewModelsBundleResource() resource.Resource {
+	return &BundleResource{kind: "model"}
+}
+
+// BundleResource defines the resource implementation shared by the tools,
+// functions and models bundles.
+type BundleResource struct {
+	kind  string
+	items bundleItemsClient
+}
+
+// bundleItemsClient manages the items of one kind of bundle
+type bundleItemsClient struct {
+	list   func() (map[string]bool, error)
+	upsert func(item []byte, exists bool) error
+	delete func(id string) error
+	// sync replaces all items with the given ones at once, if the API allows it
+	sync func(items [][]byte, existing map[string]bool) error
+	// export returns all items as exported, to send kept items back to sync
+	export func() ([]json.RawMessage, error)
+}
+
+// BundleResourceModel describes the resource data model.
+type BundleResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Source        types.String `tfsdk:"source"`
+	Content       types.String `tfsdk:"content"`
+	Items         types.Map    `tfsdk:"items"`
+	Authoritative types.Bool   `tfsdk:"authoritative"`
+	KeepIDs       types.Set    `tfsdk:"keep_ids"`
+}
+
+// bundleItem is an item of an export along with its hash
+type bundleItem struct {
+	raw  []byte
+	hash string
+}
+
+func (r *BundleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind + "s_bundle"
+}
+
+func (r *BundleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages every %s of an OpenWebUI %ss export, as produced by the UI or `/api/v1/%ss/export`. ", r.kind, r.kind, r.kind) +
+			fmt.Sprintf("Each %s is created, updated or deleted as the export changes. Existing %ss with the same ID are taken over.\n\n", r.kind, r.kind) +
+			fmt.Sprintf("Drift is only detected for %ss that are deleted outside of Terraform, which are created again. Changes made to a %s in the UI are not detected and are only overwritten once the %s changes in the export.", r.kind, r.kind, r.kind),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bundle identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path of the export JSON file. Exactly one of `source` or `content` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Inline export JSON",
+				Optional:            true,
+			},
+			"items": schema.MapAttribute{
+				MarkdownDescription: fmt.Sprintf("Hash of each managed %s by ID. In authoritative mode, %ss missing from the export show up with an empty hash until they are deleted", r.kind, r.kind),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: fmt.Sprintf("Delete every %s that is not in the export or in `keep_ids`. Defaults to `false`. ", r.kind) +
+					fmt.Sprintf("**Warning:** this includes %ss managed by `openwebui_%s` resources or by another bundle, which then delete and recreate each other on every apply. ", r.kind, r.kind) +
+					fmt.Sprintf("List their IDs in `keep_ids`, and use at most one authoritative bundle of %ss", r.kind),
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"keep_ids": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("IDs of %ss managed elsewhere, e.g. by `openwebui_%s` resources, that authoritative mode leaves untouched. IDs must not also be in the export", r.kind, r.kind),
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *BundleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	switch r.kind {
+	case "tool":
+		client, ok := clients["tools"].(*tools.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *tools.Client, got: %T. Please report this issue to the provider developers.", clients["tools"]),
+			)
+			return
+		}
+		r.items = toolsBundleClient(client)
+	case "function":
+		client, ok := clients["functions"].(*functions.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *functions.Client, got: %T. Please report this issue to the provider developers.", clients["functions"]),
+			)
+			return
+		}
+		r.items = functionsBundleClient(client)
+	case "model":
+		client, ok := clients["models"].(*models.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *models.Client, got: %T. Please report this issue to the provider developers.", clients["models"]),
+			)
+			return
+		}
+		r.items = modelsBundleClient(client)
+	}
+}
+
+func (r *BundleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to parse on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() || plan.Content.IsUnknown() {
+		return
+	}
+
+	items, diags := bundleResourceItems(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.KeepIDs.IsUnknown() {
+		keep, diags := bundleKeepIDs(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		for _, id := range sortedKeys(keep) {
+			if _, ok := items[id]; ok {
+				resp.Diagnostics.AddAttributeError(path.Root("keep_ids"), "Invalid Keep IDs", fmt.Sprintf("%s %s is in the export, it cannot also be kept", r.kind, id))
+			}
+		}
+	}
+
+	hashes, diags := bundleItemHashes(ctx, items)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("items"), hashes)...)
+}
+
+func (r *BundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BundleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := bundleResourceItems(&data)
+	resp.Diagnostics.Append(diags...)
+	keep, diags := bundleKeepIDs(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied, diags := r.apply(items, map[string]string{}, data.Authoritative.ValueBool(), keep)
+	resp.Diagnostics.Append(diags...)
+
+	// The bundle has no identity in OpenWebUI, derive one from its initial items
+	data.ID = types.StringValue(fileContentHash([]byte(strings.Join(sortedKeys(items), "\n"))))
+
+	// Save data into Terraform state, including the items applied before a failure
+	data.Items, diags = types.MapValueFrom(ctx, types.StringType, applied)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BundleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.items.list()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list %ss, got error: %s", r.kind, err))
+		return
+	}
+
+	// Only check that each item still exists: the API returns items in a
+	// different shape than the export, so their hashes cannot be compared.
+	// Forget items deleted outside of Terraform so that they are created again
+	var prior map[string]string
+	resp.Diagnostics.Append(data.Items.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for id := range prior {
+		if !existing[id] {
+			delete(prior, id)
+		}
+	}
+
+	// Surface items created outside of Terraform so that they get deleted
+	if data.Authoritative.ValueBool() {
+		keep, diags := bundleKeepIDs(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for id := range existing {
+			if _, ok := prior[id]; !ok && !keep[id] {
+				prior[id] = ""
+			}
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Items, diags = types.MapValueFrom(ctx, types.StringType, prior)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state BundleResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := bundleResourceItems(&data)
+	resp.Diagnostics.Append(diags...)
+	keep, diags := bundleKeepIDs(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	var prior map[string]string
+	resp.Diagnostics.Append(state.Items.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied, diags := r.apply(items, prior, data.Authoritative.ValueBool(), keep)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state, including the items applied before a failure
+	data.Items, diags = types.MapValueFrom(ctx, types.StringType, applied)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BundleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior map[string]string
+	resp.Diagnostics.Append(data.Items.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.apply(map[string]bundleItem{}, prior, false, nil)
+	resp.Diagnostics.Append(diags...)
+}
+
+// apply creates, updates and deletes items so that OpenWebUI matches the
+// export, starting from the item hashes of the prior state. In authoritative
+// mode, items that are neither in the export nor kept are deleted as well.
+// Kept items are never deleted, even when they were managed before. It
+// returns the hashes of the items managed afterwards, also when it fails
+// halfway.
+func (r *BundleResource) apply(items map[string]bundleItem, prior map[string]string, authoritative bool, keep map[string]bool) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	applied := make(map[string]string, len(prior))
+	for id, hash := range prior {
+		applied[id] = hash
+	}
+
+	existing, err := r.items.list()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list %ss, got error: %s", r.kind, err))
+		return applied, diags
+	}
+
+	if authoritative && r.items.sync != nil {
+		ids := sortedKeys(items)
+		raw := make([][]byte, len(ids))
+		for i, id := range ids {
+			raw[i] = items[id].raw
+		}
+
+		// Sync deletes everything it is not given, send kept items back as they are
+		kept, err := r.keptItems(keep, existing)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to export %ss, got error: %s", r.kind, err))
+			return applied, diags
+		}
+		raw = append(raw, kept...)
+
+		if err := r.items.sync(raw, existing); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to sync %ss, got error: %s", r.kind, err))
+			return applied, diags
+		}
+
+		applied = make(map[string]string, len(items))
+		for id, item := range items {
+			applied[id] = item.hash
+		}
+		return applied, diags
+	}
+
+	for _, id := range sortedKeys(prior) {
+		if _, ok := items[id]; ok {
+			continue
+		}
+		if existing[id] && !keep[id] {
+			if err := r.items.delete(id); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete %s %s, got error: %s", r.kind, id, err))
+				return applied, diags
+			}
+		}
+		delete(applied, id)
+	}
+
+	for _, id := range sortedKeys(items) {
+		if prior[id] == items[id].hash && existing[id] {
+			continue
+		}
+		if err := r.items.upsert(items[id].raw, existing[id]); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to apply %s %s, got error: %s", r.kind, id, err))
+			return applied, diags
+		}
+		applied[id] = items[id].hash
+	}
+
+	if authoritative {
+		for _, id := range sortedKeys(existing) {
+			if _, ok := items[id]; ok || keep[id] {
+				continue
+			}
+			if err := r.items.delete(id); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete %s %s, got error: %s", r.kind, id, err))
+				return applied, diags
+			}
+			delete(applied, id)
+		}
+	}
+
+	return applied, diags
+}
+
+// keptItems returns the exported copy of each kept item that exists.
+func (r *BundleResource) keptItems(keep map[string]bool, existing map[string]bool) ([][]byte, error) {
+	var kept [][]byte
+	if len(keep) == 0 {
+		return kept, nil
+	}
+
+	exported, err := r.items.export()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range exported {
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(item, &header); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %v", r.kind, err)
+		}
+		if keep[header.ID] && existing[header.ID] {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
+
+// bundleKeepIDs returns the IDs of the kept items of the resource.
+func bundleKeepIDs(ctx context.Context, data *BundleResourceModel) (map[string]bool, diag.Diagnostics) {
+	keep := map[string]bool{}
+	if data.KeepIDs.IsNull() || data.KeepIDs.IsUnknown() {
+		return keep, nil
+	}
+
+	var ids []string
+	diags := data.KeepIDs.ElementsAs(ctx, &ids, false)
+	for _, id := range ids {
+		keep[id] = true
+	}
+	return keep, diags
+}
+
+// bundleResourceItems reads and parses the export of the resource.
+func bundleResourceItems(data *BundleResourceModel) (map[string]bundleItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	content := []byte(data.Content.ValueString())
+	attribute := path.Root("content")
+	if !data.Source.IsNull() {
+		attribute = path.Root("source")
+		var err error
+		if content, err = os.ReadFile(data.Source.ValueString()); err != nil {
+			diags.AddAttributeError(attribute, "Unable to Read File", err.Error())
+			return nil, diags
+		}
+	}
+
+	items, err := parseBundle(content)
+	if err != nil {
+		diags.AddAttributeError(attribute, "Invalid Export", err.Error())
+	}
+	return items, diags
+}
+
+// bundleItemHashes returns the hash of each item as a map value.
+func bundleItemHashes(ctx context.Context, items map[string]bundleItem) (types.Map, diag.Diagnostics) {
+	hashes := make(map[string]string, len(items))
+	for id, item := range items {
+		hashes[id] = item.hash
+	}
+	return types.MapValueFrom(ctx, types.StringType, hashes)
+}
+
+// parseBundle parses an OpenWebUI export, a JSON array of items, into its
+// items by ID. Item hashes ignore fields that change with every export.
+func parseBundle(content []byte) (map[string]bundleItem, error) {
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of items: %v", err)
+	}
+
+	items := make(map[string]bundleItem, len(raw))
+	for i, item := range raw {
+		id, ok := item["id"].(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("item %d has no id", i)
+		}
+		if _, ok := items[id]; ok {
+			return nil, fmt.Errorf("item %s appears more than once", id)
+		}
+
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding item %s: %v", id, err)
+		}
+
+		for _, key := range bundleVolatileKeys {
+			delete(item, key)
+		}
+		stable, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding item %s: %v", id, err)
+		}
+
+		items[id] = bundleItem{raw: encoded, hash: fileContentHash(stable)}
+	}
+
+	return items, nil
+}
+
+// sortedKeys returns the keys of a map in order, so that items are applied
+// in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toolsBundleClient(client *tools.Client) bundleItemsClient {
+	return bundleItemsClient{
+		list: func() (map[string]bool, error) {
+			items, err := client.List()
+			if err != nil {
+				return nil, err
+			}
+			ids := make(map[string]bool, len(items))
+			for _, item := range items {
+				ids[item.ID] = true
+			}
+			return ids, nil
+		},
+		upsert: func(item []byte, exists bool) error {
+			var tool tools.APITool
+			if err := json.Unmarshal(item, &tool); err != nil {
+				return fmt.Errorf("error decoding tool: %v", err)
+			}
+
+			// Send the item as exported, APITool does not declare every field
+			var err error
+			if exists {
+				_, err = client.UpdateRaw(tool.ID, item)
+			} else {
+				_, err = client.CreateRaw(item)
+			}
+			return err
+		},
+		delete: client.Delete,
+	}
+}
+
+func functionsBundleClient(client *functions.Client) bundleItemsClient {
+	return bundleItemsClient{
+		list: func() (map[string]bool, error) {
+			items, err := client.List()
+			if err != nil {
+				return nil, err
+			}
+			ids := make(map[string]bool, len(items))
+			for _, item := range items {
+				ids[item.ID] = true
+			}
+			return ids, nil
+		},
+		upsert: func(item []byte, exists bool) error {
+			var function functions.APIFunction
+			if err := json.Unmarshal(item, &function); err != nil {
+				return fmt.Errorf("error decoding function: %v", err)
+			}
+
+			// Send the item as exported, APIFunction does not declare every field
+			var result *functions.APIFunction
+			var err error
+			if exists {
+				result, err = client.UpdateRaw(function.ID, item)
+			} else {
+				result, err = client.CreateRaw(item)
+			}
+			if err != nil {
+				return err
+			}
+
+			// The API ignores is_active and is_global in the request body
+			if result.IsActive != function.IsActive {
+				if _, err := client.Toggle(function.ID); err != nil {
+					return err
+				}
+			}
+			if result.IsGlobal != function.IsGlobal {
+				if _, err := client.ToggleGlobal(function.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		delete: client.Delete,
+		export: client.Export,
+		sync: func(items [][]byte, existing map[string]bool) error {
+			payload := make([]json.RawMessage, len(items))
+			for i, item := range items {
+				var function map[string]interface{}
+				if err := json.Unmarshal(item, &function); err != nil {
+					return fmt.Errorf("error decoding function: %v", err)
+				}
+
+				// Sync overwrites valves, keep the current ones of functions
+				// exported without them, e.g. managed by openwebui_function_valves
+				id, _ := function["id"].(string)
+				if _, ok := function["valves"]; !ok && existing[id] {
+					valves, err := client.GetValves(id)
+					if err != nil {
+						return err
+					}
+					function["valves"] = valves
+				}
+
+				encoded, err := json.Marshal(function)
+				if err != nil {
+					return fmt.Errorf("error encoding function: %v", err)
+				}
+				payload[i] = encoded
+			}
+
+			_, err := client.Sync(payload)
+			return err
+		},
+	}
+}
+
+func modelsBundleClient(client *models.Client) bundleItemsClient {
+	return bundleItemsClient{
+		list: func() (map[string]bool, error) {
+			items, err := client.GetModels()
+			if err != nil {
+				return nil, err
+			}
+			ids := make(map[string]bool, len(items))
+			for _, item := range items {
+				ids[item.ID.ValueString()] = true
+			}
+			return ids, nil
+		},
+		upsert: func(item []byte, exists bool) error {
+			var model models.APIModel
+			if err := json.Unmarshal(item, &model); err != nil {
+				return fmt.Errorf("error decoding model: %v", err)
+			}
+
+			// Send the item as exported, APIModel does not declare every field
+			var result *models.APIModel
+			var err error
+			if exists {
+				result, err = client.UpdateRawModel(model.ID, item)
+			} else {
+				result, err = client.CreateRawModel(item)
+			}
+			if err != nil {
+				return err
+			}
+
+			// The API ignores is_active in the request body
+			if result.IsActive != model.IsActive {
+				if _, err := client.ToggleModel(model.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		delete: client.DeleteModel,
+		export: client.ExportModels,
+		sync: func(items [][]byte, existing map[string]bool) error {
+			payload := make([]json.RawMessage, len(items))
+			for i, item := range items {
+				payload[i] = item
+			}
+
+			_, err := client.SyncModels(payload)
+			return err
+		},
+	}
+}
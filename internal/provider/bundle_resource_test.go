@@ -0,0 +1,133 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-openwebui/internal/provider/client/models"
+)
+
+func TestParseBundle(t *testing.T) {
+	items, err := parseBundle([]byte(`[
+		{"id": "web_search", "name": "Web Search", "content": "a", "user_id": "u1", "updated_at": 1},
+		{"id": "calculator", "name": "Calculator", "content": "b"}
+	]`))
+	if err != nil {
+		t.Fatalf("parseBundle returned error: %v", err)
+	}
+	if len(items) != 2 || items["web_search"].hash == "" || items["calculator"].hash == "" {
+		t.Fatalf("Expected two hashed items, got %v", items)
+	}
+
+	// Exports from another instance or at another time hash the same
+	reexported, err := parseBundle([]byte(`[{"id": "web_search", "name": "Web Search", "content": "a", "user_id": "u2", "updated_at": 2}]`))
+	if err != nil {
+		t.Fatalf("parseBundle returned error: %v", err)
+	}
+	if reexported["web_search"].hash != items["web_search"].hash {
+		t.Errorf("Expected volatile fields to be ignored in the hash")
+	}
+
+	changed, err := parseBundle([]byte(`[{"id": "web_search", "name": "Web Search", "content": "c"}]`))
+	if err != nil {
+		t.Fatalf("parseBundle returned error: %v", err)
+	}
+	if changed["web_search"].hash == items["web_search"].hash {
+		t.Errorf("Expected content changes to change the hash")
+	}
+}
+
+func TestParseBundleErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"not an array": `{"id": "web_search"}`,
+		"missing id":   `[{"name": "Web Search"}]`,
+		"duplicate id": `[{"id": "web_search"}, {"id": "web_search"}]`,
+	} {
+		if _, err := parseBundle([]byte(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestModelsBundleUpsertKeepsUndeclaredFields(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &sent)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	items, err := parseBundle([]byte(`[{"id": "assistant", "name": "Assistant", "meta": {"suggestion_prompts": [{"content": "Hi"}]}}]`))
+	if err != nil {
+		t.Fatalf("parseBundle returned error: %v", err)
+	}
+
+	client := modelsBundleClient(models.NewClient(server.URL, "token"))
+	if err := client.upsert(items["assistant"].raw, false); err != nil {
+		t.Fatalf("upsert returned error: %v", err)
+	}
+
+	meta, _ := sent["meta"].(map[string]interface{})
+	if _, ok := meta["suggestion_prompts"]; !ok {
+		t.Errorf("Expected meta.suggestion_prompts to be sent, got %v", sent)
+	}
+}
+
+func TestBundleApplyKeepsIDs(t *testing.T) {
+	items, err := parseBundle([]byte(`[{"id": "declared"}]`))
+	if err != nil {
+		t.Fatalf("parseBundle returned error: %v", err)
+	}
+	keep := map[string]bool{"managed": true}
+
+	// Without sync, kept items are skipped by the delete loop
+	var deleted []string
+	r := &BundleResource{kind: "tool", items: bundleItemsClient{
+		list: func() (map[string]bool, error) {
+			return map[string]bool{"declared": true, "managed": true, "adhoc": true}, nil
+		},
+		upsert: func(item []byte, exists bool) error { return nil },
+		delete: func(id string) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}}
+	applied, diags := r.apply(items, map[string]string{}, true, keep)
+	if diags.HasError() {
+		t.Fatalf("apply returned error: %v", diags)
+	}
+	if len(deleted) != 1 || deleted[0] != "adhoc" {
+		t.Errorf("Expected only 'adhoc' to be deleted, got %v", deleted)
+	}
+	if _, ok := applied["managed"]; ok {
+		t.Errorf("Expected kept item not to be managed, got %v", applied)
+	}
+
+	// With sync, kept items are sent back as exported
+	var synced []string
+	r.items.sync = func(raw [][]byte, existing map[string]bool) error {
+		for _, item := range raw {
+			synced = append(synced, string(item))
+		}
+		return nil
+	}
+	r.items.export = func() ([]json.RawMessage, error) {
+		return []json.RawMessage{
+			json.RawMessage(`{"id":"managed","name":"Managed"}`),
+			json.RawMessage(`{"id":"adhoc"}`),
+		}, nil
+	}
+	if _, diags := r.apply(items, map[string]string{}, true, keep); diags.HasError() {
+		t.Fatalf("apply returned error: %v", diags)
+	}
+	if len(synced) != 2 || synced[1] != `{"id":"managed","name":"Managed"}` {
+		t.Errorf("Expected the declared and kept items to be synced, got %v", synced)
+	}
+}
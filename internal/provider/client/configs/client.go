@@ -10,13 +10,16 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 )
 
 const (
-	basePath               = "/api/v1/configs"
-	connectionsPath        = basePath + "/connections"
-	toolServersPath        = basePath + "/tool_servers"
-	modelsPath             = basePath + "/models"
+	basePath                 = "/api/v1/configs"
+	connectionsPath          = basePath + "/connections"
+	toolServersPath          = basePath + "/tool_servers"
+	toolServersVerifyPath    = toolServersPath + "/verify"
+	modelsPath               = basePath + "/models"
+	oauthClientsRegisterPath = basePath + "/oauth/clients/register"
 )
 
 // Client implements the configs operations
@@ -169,6 +172,38 @@ func (c *Client) UpdateToolServers(config *APIToolServersConfig) (*APIToolServer
 	return &updatedConfig, nil
 }
 
+// VerifyToolServer checks that a tool server connection is reachable with the
+// given credentials. The returned error carries the server's explanation.
+func (c *Client) VerifyToolServer(connection *APIToolServerConnection) error {
+	payload, err := json.Marshal(connection)
+	if err != nil {
+		return fmt.Errorf("error marshaling connection: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, toolServersVerifyPath), bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] VerifyToolServer response status: %d", resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
 // GetModels retrieves the models configuration
 func (c *Client) GetModels() (*APIModelsConfig, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s", c.endpoint, modelsPath), nil)
@@ -236,3 +271,53 @@ func (c *Client) UpdateModels(config *APIModelsConfig) (*APIModelsConfig, error)
 
 	return &updatedConfig, nil
 }
+
+// RegisterOAuthClient registers an OAuth client for a tool server using
+// dynamic client registration. clientType (e.g. "mcp") prefixes the client ID
+// on the server side; it is omitted from the request when empty.
+func (c *Client) RegisterOAuthClient(form *APIOAuthClientRegistrationForm, clientType string) (*APIOAuthClientRegistration, error) {
+	payload, err := json.Marshal(form)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling form: %v", err)
+	}
+
+	log.Printf("[DEBUG] RegisterOAuthClient request payload: %s", string(payload))
+
+	requestURL := fmt.Sprintf("%s%s", c.endpoint, oauthClientsRegisterPath)
+	if clientType != "" {
+		requestURL = fmt.Sprintf("%s?type=%s", requestURL, url.QueryEscape(clientType))
+	}
+
+	req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The response carries the encrypted client secret, so only the status is logged
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] RegisterOAuthClient response status: %d", resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var registration APIOAuthClientRegistration
+	if err := json.Unmarshal(bodyBytes, &registration); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if !registration.Status {
+		return nil, fmt.Errorf("OAuth client registration was not successful: %s", string(bodyBytes))
+	}
+
+	return &registration, nil
+}
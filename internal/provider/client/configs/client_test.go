@@ -0,0 +1,146 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package configs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterOAuthClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/configs/oauth/clients/register" {
+			t.Errorf("Expected path '/api/v1/configs/oauth/clients/register', got %s", r.URL.Path)
+		}
+		if r.Method != "POST" {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Query().Get("type") != "mcp" {
+			t.Errorf("Expected type 'mcp', got '%s'", r.URL.Query().Get("type"))
+		}
+
+		var form APIOAuthClientRegistrationForm
+		if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if form.URL != "https://mcp.example.com" {
+			t.Errorf("Expected URL 'https://mcp.example.com', got '%s'", form.URL)
+		}
+		if form.ClientID != "github" {
+			t.Errorf("Expected client ID 'github', got '%s'", form.ClientID)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&APIOAuthClientRegistration{Status: true, OAuthClientInfo: "encrypted"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	registration, err := client.RegisterOAuthClient(&APIOAuthClientRegistrationForm{
+		URL:      "https://mcp.example.com",
+		ClientID: "github",
+	}, "mcp")
+
+	if err != nil {
+		t.Fatalf("RegisterOAuthClient returned error: %v", err)
+	}
+
+	if registration.OAuthClientInfo != "encrypted" {
+		t.Errorf("Expected oauth_client_info 'encrypted', got '%s'", registration.OAuthClientInfo)
+	}
+}
+
+func TestRegisterOAuthClientUnsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("Expected no query string, got '%s'", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&APIOAuthClientRegistration{Status: false})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.RegisterOAuthClient(&APIOAuthClientRegistrationForm{
+		URL:      "https://mcp.example.com",
+		ClientID: "github",
+	}, "")
+
+	if err == nil {
+		t.Fatal("Expected RegisterOAuthClient to return an error")
+	}
+}
+
+func TestGetToolServers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/configs/tool_servers" {
+			t.Errorf("Expected path '/api/v1/configs/tool_servers', got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"TOOL_SERVER_CONNECTIONS": [{
+			"url": "https://tools.example.com",
+			"path": "openapi.json",
+			"type": "openapi",
+			"auth_type": "bearer",
+			"key": null,
+			"config": {
+				"enable": true,
+				"access_control": {"read": {"group_ids": ["engineering"], "user_ids": []}},
+				"function_name_filter_list": "search,fetch"
+			},
+			"info": {"id": "tools", "name": "Tools", "description": "Internal tools"},
+			"spec_type": "url",
+			"spec": ""
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	apiConfig, err := client.GetToolServers()
+	if err != nil {
+		t.Fatalf("GetToolServers returned error: %v", err)
+	}
+
+	config := APIToToolServersConfig(apiConfig)
+	if len(config.ToolServerConnections) != 1 {
+		t.Fatalf("Expected 1 connection, got %d", len(config.ToolServerConnections))
+	}
+
+	conn := config.ToolServerConnections[0]
+	if conn.AuthType.ValueString() != "bearer" {
+		t.Errorf("Expected auth type 'bearer', got '%s'", conn.AuthType.ValueString())
+	}
+	if !conn.Key.IsNull() {
+		t.Errorf("Expected null key, got '%s'", conn.Key.ValueString())
+	}
+	if conn.Config == nil {
+		t.Fatal("Expected config to be set")
+	}
+	if !conn.Config.Enable.ValueBool() {
+		t.Error("Expected config to be enabled")
+	}
+	if conn.Config.FunctionNameFilterList.ValueString() != "search,fetch" {
+		t.Errorf("Expected function name filter list 'search,fetch', got '%s'", conn.Config.FunctionNameFilterList.ValueString())
+	}
+	if conn.Config.AccessControl == nil || conn.Config.AccessControl.Read == nil ||
+		len(conn.Config.AccessControl.Read.GroupIDs) != 1 || conn.Config.AccessControl.Read.GroupIDs[0].ValueString() != "engineering" {
+		t.Errorf("Expected read access for group 'engineering', got %+v", conn.Config.AccessControl)
+	}
+	if conn.Info == nil || conn.Info.Name.ValueString() != "Tools" {
+		t.Errorf("Expected info name 'Tools', got %+v", conn.Info)
+	}
+	if conn.SpecType.ValueString() != "url" {
+		t.Errorf("Expected spec type 'url', got '%s'", conn.SpecType.ValueString())
+	}
+	if conn.Spec.IsNull() || conn.Spec.ValueString() != "" {
+		t.Errorf("Expected empty spec, got %s", conn.Spec)
+	}
+}
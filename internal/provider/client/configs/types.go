@@ -5,6 +5,8 @@ package configs
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 // ConnectionsConfig represents the Terraform schema model for connections config
@@ -33,22 +35,93 @@ type APIToolServersConfig struct {
 
 // ToolServerConnection represents a single tool server connection
 type ToolServerConnection struct {
-	URL      types.String `tfsdk:"url"`
-	Path     types.String `tfsdk:"path"`
-	Type     types.String `tfsdk:"type"`
-	AuthType types.String `tfsdk:"auth_type"`
-	Key      types.String `tfsdk:"key"`
-	Config   types.Map    `tfsdk:"config"`
+	URL      types.String                `tfsdk:"url"`
+	Path     types.String                `tfsdk:"path"`
+	Type     types.String                `tfsdk:"type"`
+	AuthType types.String                `tfsdk:"auth_type"`
+	Key      types.String                `tfsdk:"key"`
+	Config   *ToolServerConnectionConfig `tfsdk:"config"`
+	Info     *ToolServerInfo             `tfsdk:"info"`
+	SpecType types.String                `tfsdk:"spec_type"`
+	Spec     types.String                `tfsdk:"spec"`
+	Verify   types.Bool                  `tfsdk:"verify"`
+}
+
+// ToolServerConnectionEntry represents a single tool server connection managed
+// on its own inside the tool servers config
+type ToolServerConnectionEntry struct {
+	ID types.String `tfsdk:"id"`
+	ToolServerConnection
 }
 
 // APIToolServerConnection represents the API tool server connection
+// AuthType, Key and Config are required by the API but may be null.
 type APIToolServerConnection struct {
-	URL      string                 `json:"url"`
-	Path     string                 `json:"path"`
-	Type     string                 `json:"type,omitempty"`
-	AuthType string                 `json:"auth_type,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	Config   map[string]interface{} `json:"config,omitempty"`
+	URL      string                         `json:"url"`
+	Path     string                         `json:"path"`
+	Type     string                         `json:"type,omitempty"`
+	AuthType *string                        `json:"auth_type"`
+	Key      *string                        `json:"key"`
+	Config   *APIToolServerConnectionConfig `json:"config"`
+	Info     *APIToolServerInfo             `json:"info,omitempty"`
+	SpecType *string                        `json:"spec_type,omitempty"`
+	Spec     *string                        `json:"spec,omitempty"`
+}
+
+// ToolServerConnectionConfig holds the settings of a tool server connection
+type ToolServerConnectionConfig struct {
+	Enable                 types.Bool            `tfsdk:"enable"`
+	AccessControl          *models.AccessControl `tfsdk:"access_control"`
+	FunctionNameFilterList types.String          `tfsdk:"function_name_filter_list"`
+}
+
+// APIToolServerConnectionConfig represents the API tool server connection config
+// A null AccessControl makes the tool server available to all users.
+type APIToolServerConnectionConfig struct {
+	Enable                 *bool                    `json:"enable,omitempty"`
+	AccessControl          *models.APIAccessControl `json:"access_control"`
+	FunctionNameFilterList *string                  `json:"function_name_filter_list,omitempty"`
+}
+
+// ToolServerInfo holds the identity of a tool server connection
+type ToolServerInfo struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	OAuthClientInfo types.String `tfsdk:"oauth_client_info"`
+}
+
+// APIToolServerInfo represents the API tool server info
+// OAuthClientInfo is the encrypted blob returned by the OAuth client
+// registration endpoint; OpenWebUI decrypts it when the connection is saved.
+type APIToolServerInfo struct {
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	OAuthClientInfo string `json:"oauth_client_info,omitempty"`
+}
+
+// OAuthClient represents the Terraform schema model for a registered OAuth client
+type OAuthClient struct {
+	ID              types.String `tfsdk:"id"`
+	URL             types.String `tfsdk:"url"`
+	ClientID        types.String `tfsdk:"client_id"`
+	ClientName      types.String `tfsdk:"client_name"`
+	Type            types.String `tfsdk:"type"`
+	OAuthClientInfo types.String `tfsdk:"oauth_client_info"`
+}
+
+// APIOAuthClientRegistrationForm represents the OAuth client registration request
+type APIOAuthClientRegistrationForm struct {
+	URL        string  `json:"url"`
+	ClientID   string  `json:"client_id"`
+	ClientName *string `json:"client_name,omitempty"`
+}
+
+// APIOAuthClientRegistration represents the OAuth client registration response
+type APIOAuthClientRegistration struct {
+	Status          bool   `json:"status"`
+	OAuthClientInfo string `json:"oauth_client_info"`
 }
 
 // ModelsConfig represents the Terraform schema model for models config
@@ -81,21 +154,49 @@ func APIToToolServersConfig(apiConfig *APIToolServersConfig) *ToolServersConfig
 	}
 
 	for i, conn := range apiConfig.ToolServerConnections {
-		config.ToolServerConnections[i] = ToolServerConnection{
-			URL:      types.StringValue(conn.URL),
-			Path:     types.StringValue(conn.Path),
-			Type:     types.StringValue(conn.Type),
-			AuthType: types.StringValue(conn.AuthType),
-			Key:      types.StringValue(conn.Key),
+		config.ToolServerConnections[i] = APIToToolServerConnection(&conn)
+	}
+
+	return config
+}
+
+// APIToToolServerConnection converts a single API tool server connection to the Terraform model
+func APIToToolServerConnection(conn *APIToolServerConnection) ToolServerConnection {
+	connection := ToolServerConnection{
+		URL:      types.StringValue(conn.URL),
+		Path:     types.StringValue(conn.Path),
+		Type:     types.StringValue(conn.Type),
+		AuthType: types.StringPointerValue(conn.AuthType),
+		Key:      types.StringPointerValue(conn.Key),
+		SpecType: types.StringPointerValue(conn.SpecType),
+		Spec:     types.StringPointerValue(conn.Spec),
+		Verify:   types.BoolNull(),
+	}
+	if conn.Config != nil {
+		connection.Config = &ToolServerConnectionConfig{
+			Enable:                 types.BoolPointerValue(conn.Config.Enable),
+			AccessControl:          models.APIToAccessControl(conn.Config.AccessControl),
+			FunctionNameFilterList: types.StringPointerValue(conn.Config.FunctionNameFilterList),
 		}
-		// Handle Config map conversion if needed
-		if len(conn.Config) > 0 {
-			// Convert to types.Map - simplified version, could be enhanced
-			config.ToolServerConnections[i].Config = types.MapNull(types.StringType)
+	}
+	if conn.Info != nil {
+		connection.Info = &ToolServerInfo{
+			ID:              stringValueOrNull(conn.Info.ID),
+			Name:            stringValueOrNull(conn.Info.Name),
+			Description:     stringValueOrNull(conn.Info.Description),
+			OAuthClientInfo: stringValueOrNull(conn.Info.OAuthClientInfo),
 		}
 	}
 
-	return config
+	return connection
+}
+
+// stringValueOrNull maps an omitted (empty) API string to a null Terraform value
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
 }
 
 // Helper function to convert API models config to Terraform model
@@ -0,0 +1,100 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package evaluations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+const (
+	basePath   = "/api/v1/evaluations"
+	configPath = basePath + "/config"
+)
+
+// Client implements the evaluations operations
+type Client struct {
+	endpoint string
+	token    string
+}
+
+// NewClient creates a new evaluations client
+func NewClient(endpoint, token string) *Client {
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+	}
+}
+
+// GetConfig retrieves the evaluations configuration
+func (c *Client) GetConfig() (*APIEvaluationConfig, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s", c.endpoint, configPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] GetEvaluationConfig response: %s", string(bodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var config APIEvaluationConfig
+	if err := json.Unmarshal(bodyBytes, &config); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &config, nil
+}
+
+// UpdateConfig updates the evaluations configuration
+func (c *Client) UpdateConfig(config *APIEvaluationConfig) (*APIEvaluationConfig, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling config: %v", err)
+	}
+
+	log.Printf("[DEBUG] UpdateEvaluationConfig request payload: %s", string(payload))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, configPath), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] UpdateEvaluationConfig response: %s", string(bodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var updatedConfig APIEvaluationConfig
+	if err := json.Unmarshal(bodyBytes, &updatedConfig); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &updatedConfig, nil
+}
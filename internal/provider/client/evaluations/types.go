@@ -0,0 +1,134 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package evaluations
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/models"
+)
+
+// EvaluationConfig represents the Terraform schema model for the evaluations config
+type EvaluationConfig struct {
+	ID                types.String `tfsdk:"id"`
+	EnableArenaModels types.Bool   `tfsdk:"enable_arena_models"`
+	ArenaModels       []ArenaModel `tfsdk:"arena_models"`
+}
+
+// APIEvaluationConfig represents the API response/request model
+type APIEvaluationConfig struct {
+	EnableArenaModels bool            `json:"ENABLE_EVALUATION_ARENA_MODELS"`
+	ArenaModels       []APIArenaModel `json:"EVALUATION_ARENA_MODELS"`
+}
+
+// ArenaModel represents a single arena model entry
+type ArenaModel struct {
+	ID   types.String    `tfsdk:"id"`
+	Name types.String    `tfsdk:"name"`
+	Meta *ArenaModelMeta `tfsdk:"meta"`
+}
+
+// APIArenaModel represents the API arena model entry
+type APIArenaModel struct {
+	ID   string             `json:"id"`
+	Name string             `json:"name"`
+	Meta *APIArenaModelMeta `json:"meta"`
+}
+
+// ArenaModelMeta holds arena model metadata
+type ArenaModelMeta struct {
+	ProfileImageURL types.String          `tfsdk:"profile_image_url"`
+	Description     types.String          `tfsdk:"description"`
+	ModelIDs        []types.String        `tfsdk:"model_ids"`
+	FilterMode      types.String          `tfsdk:"filter_mode"`
+	AccessControl   *models.AccessControl `tfsdk:"access_control"`
+}
+
+// APIArenaModelMeta represents the API arena model metadata
+// ModelIDs and FilterMode are sent as null when unset, matching what the
+// OpenWebUI admin UI stores for arena models that compare all models.
+// A null AccessControl makes the arena model public.
+type APIArenaModelMeta struct {
+	ProfileImageURL string                   `json:"profile_image_url,omitempty"`
+	Description     *string                  `json:"description"`
+	ModelIDs        []string                 `json:"model_ids"`
+	FilterMode      *string                  `json:"filter_mode"`
+	AccessControl   *models.APIAccessControl `json:"access_control"`
+}
+
+// Helper function to convert API evaluation config to Terraform model
+func APIToEvaluationConfig(apiConfig *APIEvaluationConfig) *EvaluationConfig {
+	config := &EvaluationConfig{
+		ID:                types.StringValue("evaluations"),
+		EnableArenaModels: types.BoolValue(apiConfig.EnableArenaModels),
+	}
+
+	if len(apiConfig.ArenaModels) > 0 {
+		config.ArenaModels = make([]ArenaModel, len(apiConfig.ArenaModels))
+		for i, apiModel := range apiConfig.ArenaModels {
+			config.ArenaModels[i] = APIToArenaModel(&apiModel)
+		}
+	}
+
+	return config
+}
+
+// APIToArenaModel converts a single API arena model to the Terraform model
+func APIToArenaModel(apiModel *APIArenaModel) ArenaModel {
+	model := ArenaModel{
+		ID:   types.StringValue(apiModel.ID),
+		Name: types.StringValue(apiModel.Name),
+	}
+
+	if apiModel.Meta != nil {
+		model.Meta = &ArenaModelMeta{}
+		if apiModel.Meta.ProfileImageURL != "" {
+			model.Meta.ProfileImageURL = types.StringValue(apiModel.Meta.ProfileImageURL)
+		}
+		if apiModel.Meta.Description != nil {
+			model.Meta.Description = types.StringValue(*apiModel.Meta.Description)
+		}
+		if apiModel.Meta.FilterMode != nil {
+			model.Meta.FilterMode = types.StringValue(*apiModel.Meta.FilterMode)
+		}
+		if len(apiModel.Meta.ModelIDs) > 0 {
+			model.Meta.ModelIDs = make([]types.String, len(apiModel.Meta.ModelIDs))
+			for i, id := range apiModel.Meta.ModelIDs {
+				model.Meta.ModelIDs[i] = types.StringValue(id)
+			}
+		}
+		model.Meta.AccessControl = models.APIToAccessControl(apiModel.Meta.AccessControl)
+	}
+
+	return model
+}
+
+// ArenaModelToAPI converts a Terraform arena model to the API model
+func ArenaModelToAPI(model *ArenaModel) APIArenaModel {
+	apiModel := APIArenaModel{
+		ID:   model.ID.ValueString(),
+		Name: model.Name.ValueString(),
+	}
+
+	if model.Meta != nil {
+		apiModel.Meta = &APIArenaModelMeta{}
+		if !model.Meta.ProfileImageURL.IsNull() {
+			apiModel.Meta.ProfileImageURL = model.Meta.ProfileImageURL.ValueString()
+		}
+		if !model.Meta.Description.IsNull() {
+			apiModel.Meta.Description = model.Meta.Description.ValueStringPointer()
+		}
+		if !model.Meta.FilterMode.IsNull() {
+			apiModel.Meta.FilterMode = model.Meta.FilterMode.ValueStringPointer()
+		}
+		for _, id := range model.Meta.ModelIDs {
+			if !id.IsNull() {
+				apiModel.Meta.ModelIDs = append(apiModel.Meta.ModelIDs, id.ValueString())
+			}
+		}
+		apiModel.Meta.AccessControl = models.AccessControlToAPI(model.Meta.AccessControl)
+	}
+
+	return apiModel
+}
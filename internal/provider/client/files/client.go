@@ -0,0 +1,280 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	basePath   = "/api/v1/files"
+	uploadPath = basePath + "/"
+	searchPath = basePath + "/search"
+)
+
+// processStatusPollInterval is how often WaitForProcessing checks the file status
+var processStatusPollInterval = 2 * time.Second
+
+// Client implements the files operations
+type Client struct {
+	endpoint string
+	token    string
+}
+
+// NewClient creates a new files client
+func NewClient(endpoint, token string) *Client {
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+	}
+}
+
+// Upload uploads a file. OpenWebUI extracts and embeds its content in the
+// background; use WaitForProcessing before attaching it to a knowledge base.
+func (c *Client) Upload(filename string, content []byte) (*File, error) {
+	return c.UploadWithMetadata(filename, content, nil)
+}
+
+// UploadWithMetadata uploads a file with additional metadata stored alongside it
+func (c *Client) UploadWithMetadata(filename string, content []byte, metadata map[string]string) (*File, error) {
+	return c.upload(filename, content, metadata, true)
+}
+
+// UploadUnprocessed uploads a file without extracting or embedding its
+// content. Its content can be set later with UpdateContent.
+func (c *Client) UploadUnprocessed(filename string, content []byte) (*File, error) {
+	return c.upload(filename, content, nil, false)
+}
+
+// upload sends a file to the upload endpoint, optionally skipping processing
+func (c *Client) upload(filename string, content []byte, metadata map[string]string, process bool) (*File, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("error creating form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, fmt.Errorf("error writing form file: %v", err)
+	}
+	if len(metadata) > 0 {
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling metadata: %v", err)
+		}
+		if err := writer.WriteField("metadata", string(metadataJSON)); err != nil {
+			return nil, fmt.Errorf("error writing metadata field: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("error closing form: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("%s%s", c.endpoint, uploadPath)
+	if !process {
+		endpoint += "?process=false"
+	}
+
+	req, err := http.NewRequest("POST", endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result File
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &result, nil
+}
+
+// Get gets a file by ID. It returns nil if the file does not exist.
+func (c *Client) Get(id string) (*File, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/%s", c.endpoint, basePath, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d", resp.StatusCode)
+	}
+
+	var result File
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &result, nil
+}
+
+// Search lists the files whose filename matches a wildcard pattern such as
+// "*.pdf", without their extracted content
+func (c *Client) Search(pattern string) ([]File, error) {
+	query := url.Values{}
+	query.Set("filename", pattern)
+	query.Set("content", "false")
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s?%s", c.endpoint, searchPath, query.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// OpenWebUI answers 404 when no file matches
+	if resp.StatusCode == http.StatusNotFound {
+		return []File{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result []File
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return result, nil
+}
+
+// GetProcessStatus gets the processing status of a file
+func (c *Client) GetProcessStatus(id string) (*ProcessStatus, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/%s/process/status", c.endpoint, basePath, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d", resp.StatusCode)
+	}
+
+	var result ProcessStatus
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &result, nil
+}
+
+// WaitForProcessing polls the processing status of a file until it completes,
+// fails or the timeout expires.
+func (c *Client) WaitForProcessing(id string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := c.GetProcessStatus(id)
+		if err != nil {
+			return err
+		}
+
+		switch status.Status {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("processing of file %s failed: %s", id, status.Error)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for file %s to be processed (status %q)", timeout, id, status.Status)
+		}
+		time.Sleep(processStatusPollInterval)
+	}
+}
+
+// UpdateContent replaces the extracted text content of a file and re-embeds it
+func (c *Client) UpdateContent(id string, content string) error {
+	body, err := json.Marshal(&ContentForm{Content: content})
+	if err != nil {
+		return fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/%s/data/content/update", c.endpoint, basePath, id), bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// Delete deletes a file
+func (c *Client) Delete(id string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s%s/%s", c.endpoint, basePath, id), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
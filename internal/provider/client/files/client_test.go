@@ -0,0 +1,186 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/files/" {
+			t.Errorf("Expected path '/api/v1/files/', got %s", r.URL.Path)
+		}
+		if r.Method != "POST" {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Failed to read form file: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		if header.Filename != "policy.md" {
+			t.Errorf("Expected filename 'policy.md', got '%s'", header.Filename)
+		}
+		if string(content) != "# Policy" {
+			t.Errorf("Expected content '# Policy', got '%s'", string(content))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&File{ID: "file-1", Filename: header.Filename})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	file, err := client.Upload("policy.md", []byte("# Policy"))
+
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if file.ID != "file-1" {
+		t.Errorf("Expected ID 'file-1', got '%s'", file.ID)
+	}
+}
+
+func TestUploadUnprocessed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("process") != "false" {
+			t.Errorf("Expected process=false, got query '%s'", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&File{ID: "file-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if _, err := client.UploadUnprocessed("notes.txt", []byte("placeholder")); err != nil {
+		t.Fatalf("UploadUnprocessed returned error: %v", err)
+	}
+}
+
+func TestWaitForProcessing(t *testing.T) {
+	processStatusPollInterval = time.Millisecond
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/files/file-1/process/status" {
+			t.Errorf("Expected path '/api/v1/files/file-1/process/status', got %s", r.URL.Path)
+		}
+
+		calls++
+		status := &ProcessStatus{Status: "pending"}
+		if calls == 3 {
+			status.Status = "completed"
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.WaitForProcessing("file-1", time.Minute); err != nil {
+		t.Fatalf("WaitForProcessing returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 status checks, got %d", calls)
+	}
+}
+
+func TestWaitForProcessingFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&ProcessStatus{Status: "failed", Error: "unsupported file type"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	err := client.WaitForProcessing("file-1", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "unsupported file type") {
+		t.Fatalf("Expected processing error, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/files/search" {
+			t.Errorf("Expected path '/api/v1/files/search', got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("filename"); got != "*.pdf" {
+			t.Errorf("Expected filename pattern '*.pdf', got '%s'", got)
+		}
+		if got := r.URL.Query().Get("content"); got != "false" {
+			t.Errorf("Expected content 'false', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]File{
+			{ID: "file-1", Filename: "policy.pdf", Meta: map[string]interface{}{"content_type": "application/pdf", "size": 1024}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.Search("*.pdf")
+
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(result))
+	}
+	if result[0].ContentType() != "application/pdf" || result[0].Size() != 1024 {
+		t.Errorf("Unexpected metadata: %s, %d", result[0].ContentType(), result[0].Size())
+	}
+}
+
+func TestSearchNoMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail":"No files found matching the pattern."}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.Search("*.doc")
+
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("Expected no files, got %d", len(result))
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/files/file-1" {
+			t.Errorf("Expected path '/api/v1/files/file-1', got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	file, err := client.Get("file-1")
+
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if file != nil {
+		t.Errorf("Expected nil file, got %+v", file)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package files
+
+// File represents the API response for an uploaded file
+type File struct {
+	ID        string                 `json:"id"`
+	UserID    string                 `json:"user_id"`
+	Hash      string                 `json:"hash,omitempty"`
+	Filename  string                 `json:"filename"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+	CreatedAt int64                  `json:"created_at"`
+	UpdatedAt int64                  `json:"updated_at"`
+}
+
+// ContentType returns the MIME type from the file metadata
+func (f File) ContentType() string {
+	contentType, _ := f.Meta["content_type"].(string)
+	return contentType
+}
+
+// Size returns the size in bytes from the file metadata
+func (f File) Size() int64 {
+	size, _ := f.Meta["size"].(float64)
+	return int64(size)
+}
+
+// ProcessStatus represents the processing status of an uploaded file
+// Status is one of "pending", "completed" or "failed".
+type ProcessStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ContentForm represents the form data for replacing the extracted content of a file
+type ContentForm struct {
+	Content string `json:"content"`
+}
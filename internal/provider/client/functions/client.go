@@ -6,6 +6,7 @@ package functions
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -18,6 +19,12 @@ const (
 	listPath   = basePath + "/"
 )
 
+// ErrNotFound is returned by the valves operations when the function does not exist
+var ErrNotFound = errors.New("function not found")
+
+// notFoundDetail is the error OpenWebUI returns, with status 401, for unknown IDs
+const notFoundDetail = "We could not find what you're looking for"
+
 // Client implements the functions operations
 type Client struct {
 	endpoint string
@@ -39,6 +46,12 @@ func (c *Client) Create(function *APIFunction) (*APIFunction, error) {
 		return nil, fmt.Errorf("error marshaling function: %v", err)
 	}
 
+	return c.CreateRaw(payload)
+}
+
+// CreateRaw creates a new function from its JSON representation, e.g. an item
+// of a functions export, including fields that APIFunction does not declare
+func (c *Client) CreateRaw(payload json.RawMessage) (*APIFunction, error) {
 	log.Printf("[DEBUG] CreateFunction request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, createPath), bytes.NewBuffer(payload))
@@ -137,6 +150,12 @@ func (c *Client) Update(id string, function *APIFunction) (*APIFunction, error)
 		return nil, fmt.Errorf("error marshaling function: %v", err)
 	}
 
+	return c.UpdateRaw(id, payload)
+}
+
+// UpdateRaw updates a function from its JSON representation, e.g. an item
+// of a functions export, including fields that APIFunction does not declare
+func (c *Client) UpdateRaw(id string, payload json.RawMessage) (*APIFunction, error) {
 	log.Printf("[DEBUG] UpdateFunction request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id/%s/update", c.endpoint, basePath, id), bytes.NewBuffer(payload))
@@ -168,6 +187,47 @@ func (c *Client) Update(id string, function *APIFunction) (*APIFunction, error)
 	return &updatedFunction, nil
 }
 
+// Toggle flips the active state of a function
+func (c *Client) Toggle(id string) (*APIFunction, error) {
+	return c.toggle(id, "toggle")
+}
+
+// ToggleGlobal flips the global state of a function
+func (c *Client) ToggleGlobal(id string) (*APIFunction, error) {
+	return c.toggle(id, "toggle/global")
+}
+
+// toggle posts to one of the toggle endpoints of a function. OpenWebUI
+// ignores is_active and is_global in create and update requests.
+func (c *Client) toggle(id, endpoint string) (*APIFunction, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id/%s/%s", c.endpoint, basePath, id, endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] ToggleFunction response: %s", string(bodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var function APIFunction
+	if err := json.Unmarshal(bodyBytes, &function); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &function, nil
+}
+
 // Delete deletes a function
 func (c *Client) Delete(id string) error {
 	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s%s/id/%s/delete", c.endpoint, basePath, id), nil)
@@ -192,3 +252,166 @@ func (c *Client) Delete(id string) error {
 
 	return nil
 }
+
+// GetValves gets the current valve values of a function
+func (c *Client) GetValves(id string) (map[string]interface{}, error) {
+	return c.valvesRequest("GET", fmt.Sprintf("%s%s/id/%s/valves", c.endpoint, basePath, id), nil)
+}
+
+// GetValvesSpec gets the JSON schema of the valves of a function. It returns
+// nil if the function has no valves.
+func (c *Client) GetValvesSpec(id string) (map[string]interface{}, error) {
+	return c.valvesRequest("GET", fmt.Sprintf("%s%s/id/%s/valves/spec", c.endpoint, basePath, id), nil)
+}
+
+// UpdateValves replaces the valve values of a function
+func (c *Client) UpdateValves(id string, values map[string]interface{}) (map[string]interface{}, error) {
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling valves: %v", err)
+	}
+
+	return c.valvesRequest("POST", fmt.Sprintf("%s%s/id/%s/valves/update", c.endpoint, basePath, id), payload)
+}
+
+// valvesRequest sends a request to one of the valves endpoints
+func (c *Client) valvesRequest(method, url string, payload []byte) (map[string]interface{}, error) {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound ||
+		(resp.StatusCode == http.StatusUnauthorized && bytes.Contains(bodyBytes, []byte(notFoundDetail))) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, string(bodyBytes))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var valves map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &valves); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return valves, nil
+}
+
+// LoadURL loads the source of a function from a URL, e.g. a GitHub file or an
+// OpenWebUI community page. OpenWebUI fetches the URL, not the provider.
+func (c *Client) LoadURL(url string) (*APILoadedSource, error) {
+	payload, err := json.Marshal(map[string]string{"url": url})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling url: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/load/url", c.endpoint, basePath), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var source APILoadedSource
+	if err := json.Unmarshal(bodyBytes, &source); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &source, nil
+}
+
+// Export returns every function as exported, including fields that APIFunction
+// does not declare
+func (c *Client) Export() ([]json.RawMessage, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/export", c.endpoint, basePath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var exported []json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &exported); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return exported, nil
+}
+
+// Sync replaces all functions with the given ones: functions are created or
+// updated as needed and any function not in the list is deleted. Items are
+// full function objects, including user_id, timestamps and valves.
+func (c *Client) Sync(functions []json.RawMessage) ([]APIFunction, error) {
+	payload, err := json.Marshal(map[string]interface{}{"functions": functions})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling functions: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/sync", c.endpoint, basePath), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var synced []APIFunction
+	if err := json.Unmarshal(bodyBytes, &synced); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return synced, nil
+}
@@ -8,34 +8,38 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 // Function represents the Terraform schema model
 type Function struct {
-	ID        types.String   `tfsdk:"id"`
-	UserID    types.String   `tfsdk:"user_id"`
-	Name      types.String   `tfsdk:"name"`
-	Type      types.String   `tfsdk:"type"`
-	Content   types.String   `tfsdk:"content"`
-	Meta      *FunctionMeta  `tfsdk:"meta"`
-	IsActive  types.Bool     `tfsdk:"is_active"`
-	IsGlobal  types.Bool     `tfsdk:"is_global"`
-	UpdatedAt types.Int64    `tfsdk:"updated_at"`
-	CreatedAt types.Int64    `tfsdk:"created_at"`
+	ID            types.String          `tfsdk:"id"`
+	UserID        types.String          `tfsdk:"user_id"`
+	Name          types.String          `tfsdk:"name"`
+	Type          types.String          `tfsdk:"type"`
+	Content       types.String          `tfsdk:"content"`
+	Meta          *FunctionMeta         `tfsdk:"meta"`
+	AccessControl *models.AccessControl `tfsdk:"access_control"`
+	IsActive      types.Bool            `tfsdk:"is_active"`
+	IsGlobal      types.Bool            `tfsdk:"is_global"`
+	UpdatedAt     types.Int64           `tfsdk:"updated_at"`
+	CreatedAt     types.Int64           `tfsdk:"created_at"`
 }
 
 // APIFunction represents the API response/request model
 type APIFunction struct {
-	ID        string                 `json:"id"`
-	UserID    string                 `json:"user_id,omitempty"`
-	Name      string                 `json:"name"`
-	Type      string                 `json:"type,omitempty"`
-	Content   string                 `json:"content"`
-	Meta      *APIFunctionMeta       `json:"meta"`
-	IsActive  bool                   `json:"is_active,omitempty"`
-	IsGlobal  bool                   `json:"is_global,omitempty"`
-	UpdatedAt int64                  `json:"updated_at,omitempty"`
-	CreatedAt int64                  `json:"created_at,omitempty"`
+	ID            string                   `json:"id"`
+	UserID        string                   `json:"user_id,omitempty"`
+	Name          string                   `json:"name"`
+	Type          string                   `json:"type,omitempty"`
+	Content       string                   `json:"content"`
+	Meta          *APIFunctionMeta         `json:"meta"`
+	AccessControl *models.APIAccessControl `json:"access_control,omitempty"`
+	IsActive      bool                     `json:"is_active,omitempty"`
+	IsGlobal      bool                     `json:"is_global,omitempty"`
+	UpdatedAt     int64                    `json:"updated_at,omitempty"`
+	CreatedAt     int64                    `json:"created_at,omitempty"`
 }
 
 // FunctionMeta holds function metadata
@@ -66,7 +70,9 @@ func APIToFunction(apiFunction *APIFunction) *Function {
 
 	// Handle Meta
 	if apiFunction.Meta != nil {
-		function.Meta = &FunctionMeta{}
+		function.Meta = &FunctionMeta{
+			Manifest: types.MapNull(types.StringType),
+		}
 		if apiFunction.Meta.Description != "" {
 			function.Meta.Description = types.StringValue(apiFunction.Meta.Description)
 		}
@@ -83,5 +89,13 @@ func APIToFunction(apiFunction *APIFunction) *Function {
 		}
 	}
 
+	function.AccessControl = models.APIToAccessControl(apiFunction.AccessControl)
+
 	return function
 }
+
+// APILoadedSource represents the function source loaded from a URL
+type APILoadedSource struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
@@ -6,13 +6,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 )
 
 const (
-	basePath   = "/api/v1/knowledge"
-	createPath = basePath + "/create"
-	listPath   = basePath + "/"
+	basePath    = "/api/v1/knowledge"
+	createPath  = basePath + "/create"
+	listPath    = basePath + "/"
+	reindexPath = basePath + "/reindex"
 )
 
 // Client implements KnowledgeClient interface
@@ -170,3 +173,118 @@ func (c *Client) Delete(id string) error {
 
 	return nil
 }
+
+// Reindex re-embeds the files of every knowledge base with the current
+// retrieval settings
+func (c *Client) Reindex() error {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, reindexPath), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// AddFile adds a processed file to a knowledge base
+func (c *Client) AddFile(id string, fileID string) (*KnowledgeResponse, error) {
+	return c.postFile(fmt.Sprintf("%s%s/%s/file/add", c.endpoint, basePath, id), fileID)
+}
+
+// UpdateFile re-embeds the current content of a file in a knowledge base
+func (c *Client) UpdateFile(id string, fileID string) (*KnowledgeResponse, error) {
+	return c.postFile(fmt.Sprintf("%s%s/%s/file/update", c.endpoint, basePath, id), fileID)
+}
+
+// RemoveFile removes a file from a knowledge base, deleting the file itself
+// when deleteFile is set
+func (c *Client) RemoveFile(id string, fileID string, deleteFile bool) (*KnowledgeResponse, error) {
+	query := url.Values{}
+	query.Set("delete_file", fmt.Sprint(deleteFile))
+	return c.postFile(fmt.Sprintf("%s%s/%s/file/remove?%s", c.endpoint, basePath, id, query.Encode()), fileID)
+}
+
+// AddFilesBatch adds several processed files to a knowledge base at once
+func (c *Client) AddFilesBatch(id string, fileIDs []string) (*KnowledgeResponse, error) {
+	forms := make([]KnowledgeFileIDForm, len(fileIDs))
+	for i, fileID := range fileIDs {
+		forms[i] = KnowledgeFileIDForm{FileID: fileID}
+	}
+
+	body, err := json.Marshal(forms)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/%s/files/batch/add", c.endpoint, basePath, id), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result KnowledgeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &result, nil
+}
+
+// postFile sends a file ID form to one of the knowledge file endpoints
+func (c *Client) postFile(endpoint string, fileID string) (*KnowledgeResponse, error) {
+	body, err := json.Marshal(&KnowledgeFileIDForm{FileID: fileID})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result KnowledgeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &result, nil
+}
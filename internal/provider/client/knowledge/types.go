@@ -4,6 +4,8 @@ package knowledge
 
 import (
 	"encoding/json"
+
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 // KnowledgeClient defines the interface for knowledge operations
@@ -13,25 +15,68 @@ type KnowledgeClient interface {
 	List() ([]KnowledgeResponse, error)
 	Update(id string, form *KnowledgeForm) (*KnowledgeResponse, error)
 	Delete(id string) error
+	Reindex() error
+	AddFile(id string, fileID string) (*KnowledgeResponse, error)
+	UpdateFile(id string, fileID string) (*KnowledgeResponse, error)
+	RemoveFile(id string, fileID string, deleteFile bool) (*KnowledgeResponse, error)
+	AddFilesBatch(id string, fileIDs []string) (*KnowledgeResponse, error)
 }
 
 // KnowledgeForm represents the form data for creating/updating a knowledge base
+// A null AccessControl makes the knowledge base public; an empty one makes it
+// private to its owner.
 type KnowledgeForm struct {
-	Name          string                 `json:"name"`
-	Description   string                 `json:"description"`
-	Data          map[string]string      `json:"data,omitempty"`
-	AccessControl map[string]interface{} `json:"access_control,omitempty"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Data          map[string]string        `json:"data,omitempty"`
+	AccessControl *models.APIAccessControl `json:"access_control"`
 }
 
 // KnowledgeResponse represents the API response for a knowledge base
 type KnowledgeResponse struct {
-	ID            string                 `json:"id"`
-	Name          string                 `json:"name"`
-	Description   string                 `json:"description"`
-	Data          map[string]interface{} `json:"data,omitempty"`
-	AccessControl interface{}            `json:"access_control,omitempty"`
-	UpdatedAt     int64                  `json:"updated_at"`
-	CreatedAt     int64                  `json:"created_at"`
+	ID            string                   `json:"id"`
+	UserID        string                   `json:"user_id"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Data          map[string]interface{}   `json:"data,omitempty"`
+	AccessControl *models.APIAccessControl `json:"access_control,omitempty"`
+	UpdatedAt     int64                    `json:"updated_at"`
+	CreatedAt     int64                    `json:"created_at"`
+	Files         []KnowledgeFile          `json:"files,omitempty"`
+}
+
+// KnowledgeFile represents a file attached to a knowledge base
+type KnowledgeFile struct {
+	ID        string                 `json:"id"`
+	Hash      string                 `json:"hash,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+	CreatedAt int64                  `json:"created_at"`
+	UpdatedAt int64                  `json:"updated_at"`
+}
+
+// Status returns the processing status from the file data, if the knowledge
+// base response includes it
+func (f KnowledgeFile) Status() string {
+	status, _ := f.Data["status"].(string)
+	return status
+}
+
+// Name returns the original filename from the file metadata
+func (f KnowledgeFile) Name() string {
+	name, _ := f.Meta["name"].(string)
+	return name
+}
+
+// Size returns the size in bytes from the file metadata
+func (f KnowledgeFile) Size() int64 {
+	size, _ := f.Meta["size"].(float64)
+	return int64(size)
+}
+
+// KnowledgeFileIDForm represents the form data for adding, updating or removing a file
+type KnowledgeFileIDForm struct {
+	FileID string `json:"file_id"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for KnowledgeResponse
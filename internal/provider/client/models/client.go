@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 )
 
 // Client implements the models operations
@@ -90,145 +91,28 @@ func (c *Client) GetModels() ([]Model, error) {
 }
 
 func (c *Client) CreateModel(model *Model) (*Model, error) {
-	// Convert to API model
-	apiModel := &APIModel{
-		ID:          model.ID.ValueString(),
-		BaseModelID: model.BaseModelID.ValueString(),
-		Name:        model.Name.ValueString(),
-		IsActive:    model.IsActive.ValueBool(),
-	}
-
-	// Handle Params
-	if model.Params != nil {
-		apiModel.Params = &APIModelParams{}
-		if !model.Params.System.IsNull() {
-			apiModel.Params.System = model.Params.System.ValueString()
-		}
-		if !model.Params.StreamResponse.IsNull() {
-			apiModel.Params.StreamResponse = model.Params.StreamResponse.ValueBoolPointer()
-		}
-		if !model.Params.Temperature.IsNull() {
-			apiModel.Params.Temperature = model.Params.Temperature.ValueFloat64()
-		}
-		if !model.Params.ReasoningEffort.IsNull() {
-			apiModel.Params.ReasoningEffort = model.Params.ReasoningEffort.ValueString()
-		}
-		if !model.Params.TopP.IsNull() {
-			apiModel.Params.TopP = model.Params.TopP.ValueFloat64()
-		}
-		if !model.Params.MaxTokens.IsNull() {
-			apiModel.Params.MaxTokens = model.Params.MaxTokens.ValueInt64()
-		}
-		if !model.Params.Seed.IsNull() {
-			apiModel.Params.Seed = model.Params.Seed.ValueInt64()
-		}
-		if !model.Params.TopK.IsNull() {
-			apiModel.Params.TopK = model.Params.TopK.ValueInt64()
-		}
-		if !model.Params.MinP.IsNull() {
-			apiModel.Params.MinP = model.Params.MinP.ValueFloat64()
-		}
-		if !model.Params.FrequencyPenalty.IsNull() {
-			apiModel.Params.FrequencyPenalty = model.Params.FrequencyPenalty.ValueInt64()
-		}
-		if !model.Params.RepeatLastN.IsNull() {
-			apiModel.Params.RepeatLastN = model.Params.RepeatLastN.ValueInt64()
-		}
-		if !model.Params.NumCtx.IsNull() {
-			apiModel.Params.NumCtx = model.Params.NumCtx.ValueInt64()
-		}
-		if !model.Params.NumBatch.IsNull() {
-			apiModel.Params.NumBatch = model.Params.NumBatch.ValueInt64()
-		}
-		if !model.Params.NumKeep.IsNull() {
-			apiModel.Params.NumKeep = model.Params.NumKeep.ValueInt64()
-		}
-		if !model.Params.FunctionCalling.IsNull() {
-			apiModel.Params.FunctionCalling = model.Params.FunctionCalling.ValueStringPointer()
-		}
-	}
-
-	// Handle Meta
-	if model.Meta != nil {
-		apiModel.Meta = &APIModelMeta{}
-		if !model.Meta.ProfileImageURL.IsNull() {
-			apiModel.Meta.ProfileImageURL = model.Meta.ProfileImageURL.ValueString()
-		}
-		if !model.Meta.Description.IsNull() {
-			apiModel.Meta.Description = model.Meta.Description.ValueString()
-		}
-
-		if model.Meta.Capabilities != nil {
-			apiModel.Meta.Capabilities = &APIModelCapabilities{
-				Vision:    model.Meta.Capabilities.Vision.ValueBool(),
-				Usage:     model.Meta.Capabilities.Usage.ValueBool(),
-				Citations: model.Meta.Capabilities.Citations.ValueBool(),
-			}
-		}
-
-		if len(model.Meta.Tags) > 0 {
-			apiModel.Meta.Tags = make([]APITag, len(model.Meta.Tags))
-			for i, tag := range model.Meta.Tags {
-				if !tag.Name.IsNull() {
-					apiModel.Meta.Tags[i] = APITag{
-						Name: tag.Name.ValueString(),
-					}
-				}
-			}
-		}
-
-		if len(model.Meta.FilterIDs) > 0 {
-			apiModel.Meta.FilterIDs = make([]string, len(model.Meta.FilterIDs))
-			for i, id := range model.Meta.FilterIDs {
-				if !id.IsNull() {
-					apiModel.Meta.FilterIDs[i] = id.ValueString()
-				}
-			}
-		}
-	}
-
-	// Handle AccessControl
-	if model.AccessControl != nil {
-		apiModel.AccessControl = &APIAccessControl{}
-		if model.AccessControl.Read != nil {
-			apiModel.AccessControl.Read = &APIAccessGroup{
-				GroupIDs: make([]string, 0),
-				UserIDs:  make([]string, 0),
-			}
-			for _, id := range model.AccessControl.Read.GroupIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Read.GroupIDs = append(apiModel.AccessControl.Read.GroupIDs, id.ValueString())
-				}
-			}
-			for _, id := range model.AccessControl.Read.UserIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Read.UserIDs = append(apiModel.AccessControl.Read.UserIDs, id.ValueString())
-				}
-			}
-		}
-		if model.AccessControl.Write != nil {
-			apiModel.AccessControl.Write = &APIAccessGroup{
-				GroupIDs: make([]string, 0),
-				UserIDs:  make([]string, 0),
-			}
-			for _, id := range model.AccessControl.Write.GroupIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Write.GroupIDs = append(apiModel.AccessControl.Write.GroupIDs, id.ValueString())
-				}
-			}
-			for _, id := range model.AccessControl.Write.UserIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Write.UserIDs = append(apiModel.AccessControl.Write.UserIDs, id.ValueString())
-				}
-			}
-		}
+	createdAPIModel, err := c.CreateAPIModel(ModelToAPI(model))
+	if err != nil {
+		return nil, err
 	}
 
+	return APIToModel(createdAPIModel), nil
+}
+
+// CreateAPIModel creates a model from its API representation, e.g. an item
+// of a models export.
+func (c *Client) CreateAPIModel(apiModel *APIModel) (*APIModel, error) {
 	payload, err := json.Marshal(apiModel)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling model: %v", err)
 	}
 
+	return c.CreateRawModel(payload)
+}
+
+// CreateRawModel creates a model from its JSON representation, e.g. an item
+// of a models export, including fields that APIModel does not declare
+func (c *Client) CreateRawModel(payload json.RawMessage) (*APIModel, error) {
 	log.Printf("[DEBUG] CreateModel request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/models/create", c.endpoint), bytes.NewBuffer(payload))
@@ -257,149 +141,34 @@ func (c *Client) CreateModel(model *Model) (*Model, error) {
 		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	return APIToModel(&createdAPIModel), nil
+	return &createdAPIModel, nil
 }
 
 func (c *Client) UpdateModel(id string, model *Model) (*Model, error) {
-	// Convert to API model
-	apiModel := &APIModel{
-		ID:          id,
-		BaseModelID: model.BaseModelID.ValueString(),
-		Name:        model.Name.ValueString(),
-		IsActive:    model.IsActive.ValueBool(),
-	}
-
-	// Handle Params
-	if model.Params != nil {
-		apiModel.Params = &APIModelParams{}
-		if !model.Params.System.IsNull() {
-			apiModel.Params.System = model.Params.System.ValueString()
-		}
-		if !model.Params.StreamResponse.IsNull() {
-			apiModel.Params.StreamResponse = model.Params.StreamResponse.ValueBoolPointer()
-		}
-		if !model.Params.Temperature.IsNull() {
-			apiModel.Params.Temperature = model.Params.Temperature.ValueFloat64()
-		}
-		if !model.Params.ReasoningEffort.IsNull() {
-			apiModel.Params.ReasoningEffort = model.Params.ReasoningEffort.ValueString()
-		}
-		if !model.Params.TopP.IsNull() {
-			apiModel.Params.TopP = model.Params.TopP.ValueFloat64()
-		}
-		if !model.Params.MaxTokens.IsNull() {
-			apiModel.Params.MaxTokens = model.Params.MaxTokens.ValueInt64()
-		}
-		if !model.Params.Seed.IsNull() {
-			apiModel.Params.Seed = model.Params.Seed.ValueInt64()
-		}
-		if !model.Params.TopK.IsNull() {
-			apiModel.Params.TopK = model.Params.TopK.ValueInt64()
-		}
-		if !model.Params.MinP.IsNull() {
-			apiModel.Params.MinP = model.Params.MinP.ValueFloat64()
-		}
-		if !model.Params.FrequencyPenalty.IsNull() {
-			apiModel.Params.FrequencyPenalty = model.Params.FrequencyPenalty.ValueInt64()
-		}
-		if !model.Params.RepeatLastN.IsNull() {
-			apiModel.Params.RepeatLastN = model.Params.RepeatLastN.ValueInt64()
-		}
-		if !model.Params.NumCtx.IsNull() {
-			apiModel.Params.NumCtx = model.Params.NumCtx.ValueInt64()
-		}
-		if !model.Params.NumBatch.IsNull() {
-			apiModel.Params.NumBatch = model.Params.NumBatch.ValueInt64()
-		}
-		if !model.Params.NumKeep.IsNull() {
-			apiModel.Params.NumKeep = model.Params.NumKeep.ValueInt64()
-		}
-		if !model.Params.FunctionCalling.IsNull() {
-			apiModel.Params.FunctionCalling = model.Params.FunctionCalling.ValueStringPointer()
-		}
-	}
-
-	// Handle Meta
-	if model.Meta != nil {
-		apiModel.Meta = &APIModelMeta{}
-		if !model.Meta.ProfileImageURL.IsNull() {
-			apiModel.Meta.ProfileImageURL = model.Meta.ProfileImageURL.ValueString()
-		}
-		if !model.Meta.Description.IsNull() {
-			apiModel.Meta.Description = model.Meta.Description.ValueString()
-		}
-
-		if model.Meta.Capabilities != nil {
-			apiModel.Meta.Capabilities = &APIModelCapabilities{
-				Vision:    model.Meta.Capabilities.Vision.ValueBool(),
-				Usage:     model.Meta.Capabilities.Usage.ValueBool(),
-				Citations: model.Meta.Capabilities.Citations.ValueBool(),
-			}
-		}
-
-		if len(model.Meta.Tags) > 0 {
-			apiModel.Meta.Tags = make([]APITag, len(model.Meta.Tags))
-			for i, tag := range model.Meta.Tags {
-				if !tag.Name.IsNull() {
-					apiModel.Meta.Tags[i] = APITag{
-						Name: tag.Name.ValueString(),
-					}
-				}
-			}
-		}
-
-		if len(model.Meta.FilterIDs) > 0 {
-			apiModel.Meta.FilterIDs = make([]string, len(model.Meta.FilterIDs))
-			for i, id := range model.Meta.FilterIDs {
-				if !id.IsNull() {
-					apiModel.Meta.FilterIDs[i] = id.ValueString()
-				}
-			}
-		}
-	}
-
-	// Handle AccessControl
-	if model.AccessControl != nil {
-		apiModel.AccessControl = &APIAccessControl{}
-		if model.AccessControl.Read != nil {
-			apiModel.AccessControl.Read = &APIAccessGroup{
-				GroupIDs: make([]string, 0),
-				UserIDs:  make([]string, 0),
-			}
-			for _, id := range model.AccessControl.Read.GroupIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Read.GroupIDs = append(apiModel.AccessControl.Read.GroupIDs, id.ValueString())
-				}
-			}
-			for _, id := range model.AccessControl.Read.UserIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Read.UserIDs = append(apiModel.AccessControl.Read.UserIDs, id.ValueString())
-				}
-			}
-		}
-		if model.AccessControl.Write != nil {
-			apiModel.AccessControl.Write = &APIAccessGroup{
-				GroupIDs: make([]string, 0),
-				UserIDs:  make([]string, 0),
-			}
-			for _, id := range model.AccessControl.Write.GroupIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Write.GroupIDs = append(apiModel.AccessControl.Write.GroupIDs, id.ValueString())
-				}
-			}
-			for _, id := range model.AccessControl.Write.UserIDs {
-				if !id.IsNull() {
-					apiModel.AccessControl.Write.UserIDs = append(apiModel.AccessControl.Write.UserIDs, id.ValueString())
-				}
-			}
-		}
+	updatedAPIModel, err := c.UpdateAPIModel(id, ModelToAPI(model))
+	if err != nil {
+		return nil, err
 	}
 
+	return APIToModel(updatedAPIModel), nil
+}
+
+// UpdateAPIModel updates a model from its API representation, e.g. an item
+// of a models export.
+func (c *Client) UpdateAPIModel(id string, apiModel *APIModel) (*APIModel, error) {
+	apiModel.ID = id
+
 	payload, err := json.Marshal(apiModel)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling model: %v", err)
 	}
 
+	return c.UpdateRawModel(id, payload)
+}
+
+// UpdateRawModel updates a model from its JSON representation, e.g. an item
+// of a models export, including fields that APIModel does not declare
+func (c *Client) UpdateRawModel(id string, payload json.RawMessage) (*APIModel, error) {
 	log.Printf("[DEBUG] UpdateModel request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/models/model/update?id=%s", c.endpoint, id), bytes.NewBuffer(payload))
@@ -433,7 +202,38 @@ func (c *Client) UpdateModel(id string, model *Model) (*Model, error) {
 		updatedAPIModel.ID = id
 	}
 
-	return APIToModel(&updatedAPIModel), nil
+	return &updatedAPIModel, nil
+}
+
+// ToggleModel flips the active state of a model. OpenWebUI ignores is_active
+// in create and update requests, so this is the only way to change it.
+func (c *Client) ToggleModel(id string) (*Model, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/models/model/toggle?id=%s", c.endpoint, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] ToggleModel response: %s", string(bodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var apiModel APIModel
+	if err := json.Unmarshal(bodyBytes, &apiModel); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return APIToModel(&apiModel), nil
 }
 
 func (c *Client) DeleteModel(id string) error {
@@ -459,3 +259,97 @@ func (c *Client) DeleteModel(id string) error {
 
 	return nil
 }
+
+// ExportModels returns every model as exported, including fields that APIModel
+// does not declare
+func (c *Client) ExportModels() ([]json.RawMessage, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/models/export", c.endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var exported []json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &exported); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return exported, nil
+}
+
+// SyncModels replaces all workspace models with the given ones: models are
+// created or updated as needed and any model not in the list is deleted.
+// Items are full model objects, including user_id and timestamps.
+func (c *Client) SyncModels(models []json.RawMessage) ([]APIModel, error) {
+	payload, err := json.Marshal(map[string]interface{}{"models": models})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling models: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/models/sync", c.endpoint), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("[DEBUG] SyncModels response: %s", string(bodyBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var synced []APIModel
+	if err := json.Unmarshal(bodyBytes, &synced); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return synced, nil
+}
+
+// GetProfileImage gets the profile image served for a model, whether it is
+// stored as a data URI, links to another URL or is the default image. It
+// returns the image content and its content type.
+func (c *Client) GetProfileImage(id string) ([]byte, string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/models/model/profile/image?id=%s", c.endpoint, url.QueryEscape(id)), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return bodyBytes, resp.Header.Get("Content-Type"), nil
+}
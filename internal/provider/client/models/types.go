@@ -4,22 +4,28 @@
 package models
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Model represents the Terraform schema model
 type Model struct {
-	ID            types.String   `tfsdk:"id"`
-	UserID        types.String   `tfsdk:"user_id"`
-	BaseModelID   types.String   `tfsdk:"base_model_id"`
-	Name          types.String   `tfsdk:"name"`
-	Params        *ModelParams   `tfsdk:"params"`
-	Meta          *ModelMeta     `tfsdk:"meta"`
-	AccessControl *AccessControl `tfsdk:"access_control"`
-	IsActive      types.Bool     `tfsdk:"is_active"`
-	IsPrivate     types.Bool     `tfsdk:"is_private"`
-	UpdatedAt     types.Int64    `tfsdk:"updated_at"`
-	CreatedAt     types.Int64    `tfsdk:"created_at"`
+	ID            types.String         `tfsdk:"id"`
+	UserID        types.String         `tfsdk:"user_id"`
+	BaseModelID   types.String         `tfsdk:"base_model_id"`
+	Name          types.String         `tfsdk:"name"`
+	Params        *ModelParams         `tfsdk:"params"`
+	ExtraParams   jsontypes.Normalized `tfsdk:"extra_params"`
+	Meta          *ModelMeta           `tfsdk:"meta"`
+	AccessControl *AccessControl       `tfsdk:"access_control"`
+	IsActive      types.Bool           `tfsdk:"is_active"`
+	IsPrivate     types.Bool           `tfsdk:"is_private"`
+	UpdatedAt     types.Int64          `tfsdk:"updated_at"`
+	CreatedAt     types.Int64          `tfsdk:"created_at"`
 }
 
 // APIModel represents the API response model
@@ -37,72 +43,176 @@ type APIModel struct {
 }
 
 type ModelParams struct {
-	System           types.String  `tfsdk:"system"`
-	StreamResponse   types.Bool    `tfsdk:"stream_response"`
-	Seed             types.Int64   `tfsdk:"seed"`
-	Temperature      types.Float64 `tfsdk:"temperature"`
-	ReasoningEffort  types.String  `tfsdk:"reasoning_effort"`
-	TopK             types.Int64   `tfsdk:"top_k"`
-	TopP             types.Float64 `tfsdk:"top_p"`
-	MinP             types.Float64 `tfsdk:"min_p"`
-	FrequencyPenalty types.Int64   `tfsdk:"frequency_penalty"`
-	RepeatLastN      types.Int64   `tfsdk:"repeat_last_n"`
-	NumCtx           types.Int64   `tfsdk:"num_ctx"`
-	NumBatch         types.Int64   `tfsdk:"num_batch"`
-	NumKeep          types.Int64   `tfsdk:"num_keep"`
-	MaxTokens        types.Int64   `tfsdk:"max_tokens"`
-	FunctionCalling  types.String  `tfsdk:"function_calling"`
+	System           types.String   `tfsdk:"system"`
+	StreamResponse   types.Bool     `tfsdk:"stream_response"`
+	Seed             types.Int64    `tfsdk:"seed"`
+	Temperature      types.Float64  `tfsdk:"temperature"`
+	ReasoningEffort  types.String   `tfsdk:"reasoning_effort"`
+	TopK             types.Int64    `tfsdk:"top_k"`
+	TopP             types.Float64  `tfsdk:"top_p"`
+	MinP             types.Float64  `tfsdk:"min_p"`
+	FrequencyPenalty types.Float64  `tfsdk:"frequency_penalty"`
+	PresencePenalty  types.Float64  `tfsdk:"presence_penalty"`
+	RepeatPenalty    types.Float64  `tfsdk:"repeat_penalty"`
+	RepeatLastN      types.Int64    `tfsdk:"repeat_last_n"`
+	Mirostat         types.Int64    `tfsdk:"mirostat"`
+	MirostatEta      types.Float64  `tfsdk:"mirostat_eta"`
+	MirostatTau      types.Float64  `tfsdk:"mirostat_tau"`
+	TfsZ             types.Float64  `tfsdk:"tfs_z"`
+	Stop             []types.String `tfsdk:"stop"`
+	NumCtx           types.Int64    `tfsdk:"num_ctx"`
+	NumBatch         types.Int64    `tfsdk:"num_batch"`
+	NumKeep          types.Int64    `tfsdk:"num_keep"`
+	NumThread        types.Int64    `tfsdk:"num_thread"`
+	NumGPU           types.Int64    `tfsdk:"num_gpu"`
+	UseMmap          types.Bool     `tfsdk:"use_mmap"`
+	UseMlock         types.Bool     `tfsdk:"use_mlock"`
+	KeepAlive        types.String   `tfsdk:"keep_alive"`
+	MaxTokens        types.Int64    `tfsdk:"max_tokens"`
+	FunctionCalling  types.String   `tfsdk:"function_calling"`
 }
 
 // APIModelParams represents the API model parameters
 // FunctionCalling is a pointer so that it is omitted when not set
 // The API expects the value to be set to "native" if enabled
 // or completely omitted if unset.
+// Params without a typed field are kept in Extra so they survive an update.
 type APIModelParams struct {
-	System           string  `json:"system,omitempty"`
-	StreamResponse   *bool   `json:"stream_response,omitempty"`
-	Seed             int64   `json:"seed,omitempty"`
-	Temperature      float64 `json:"temperature,omitempty"`
-	ReasoningEffort  string  `json:"reasoning_effort,omitempty"`
-	TopK             int64   `json:"top_k,omitempty"`
-	TopP             float64 `json:"top_p,omitempty"`
-	MinP             float64 `json:"min_p,omitempty"`
-	FrequencyPenalty int64   `json:"frequency_penalty,omitempty"`
-	RepeatLastN      int64   `json:"repeat_last_n,omitempty"`
-	NumCtx           int64   `json:"num_ctx,omitempty"`
-	NumBatch         int64   `json:"num_batch,omitempty"`
-	NumKeep          int64   `json:"num_keep,omitempty"`
-	MaxTokens        int64   `json:"max_tokens,omitempty"`
-	FunctionCalling  *string `json:"function_calling,omitempty"`
+	System           string                 `json:"system,omitempty"`
+	StreamResponse   *bool                  `json:"stream_response,omitempty"`
+	Seed             int64                  `json:"seed,omitempty"`
+	Temperature      float64                `json:"temperature,omitempty"`
+	ReasoningEffort  string                 `json:"reasoning_effort,omitempty"`
+	TopK             int64                  `json:"top_k,omitempty"`
+	TopP             float64                `json:"top_p,omitempty"`
+	MinP             float64                `json:"min_p,omitempty"`
+	FrequencyPenalty *float64               `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64               `json:"presence_penalty,omitempty"`
+	RepeatPenalty    *float64               `json:"repeat_penalty,omitempty"`
+	RepeatLastN      int64                  `json:"repeat_last_n,omitempty"`
+	Mirostat         *int64                 `json:"mirostat,omitempty"`
+	MirostatEta      *float64               `json:"mirostat_eta,omitempty"`
+	MirostatTau      *float64               `json:"mirostat_tau,omitempty"`
+	TfsZ             *float64               `json:"tfs_z,omitempty"`
+	Stop             []string               `json:"stop,omitempty"`
+	NumCtx           int64                  `json:"num_ctx,omitempty"`
+	NumBatch         int64                  `json:"num_batch,omitempty"`
+	NumKeep          int64                  `json:"num_keep,omitempty"`
+	NumThread        *int64                 `json:"num_thread,omitempty"`
+	NumGPU           *int64                 `json:"num_gpu,omitempty"`
+	UseMmap          *bool                  `json:"use_mmap,omitempty"`
+	UseMlock         *bool                  `json:"use_mlock,omitempty"`
+	KeepAlive        *string                `json:"keep_alive,omitempty"`
+	MaxTokens        int64                  `json:"max_tokens,omitempty"`
+	FunctionCalling  *string                `json:"function_calling,omitempty"`
+	Extra            map[string]interface{} `json:"-"`
+}
+
+// apiModelParamsKeys are the JSON keys of the typed params
+var apiModelParamsKeys = func() map[string]bool {
+	keys := make(map[string]bool)
+	paramsType := reflect.TypeOf(APIModelParams{})
+	for i := 0; i < paramsType.NumField(); i++ {
+		name := strings.Split(paramsType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}()
+
+// IsTypedParam reports whether a param has a typed field and therefore
+// cannot be set through extra params.
+func IsTypedParam(key string) bool {
+	return apiModelParamsKeys[key]
+}
+
+// MarshalJSON merges the extra params with the typed params
+func (p APIModelParams) MarshalJSON() ([]byte, error) {
+	type Alias APIModelParams
+	typed, err := json.Marshal(Alias(p))
+	if err != nil || len(p.Extra) == 0 {
+		return typed, err
+	}
+
+	merged := make(map[string]interface{}, len(p.Extra))
+	for key, value := range p.Extra {
+		merged[key] = value
+	}
+	if err := json.Unmarshal(typed, &merged); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON decodes the typed params and keeps all others in Extra
+func (p *APIModelParams) UnmarshalJSON(data []byte) error {
+	type Alias APIModelParams
+	if err := json.Unmarshal(data, (*Alias)(p)); err != nil {
+		return err
+	}
+
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for key := range all {
+		if apiModelParamsKeys[key] {
+			delete(all, key)
+		}
+	}
+	if len(all) > 0 {
+		p.Extra = all
+	}
+	return nil
 }
 
 // ModelMeta holds model metadata
 type ModelMeta struct {
-	ProfileImageURL types.String       `tfsdk:"profile_image_url"`
-	Description     types.String       `tfsdk:"description"`
-	Capabilities    *ModelCapabilities `tfsdk:"capabilities"`
-	Tags            []Tag              `tfsdk:"tags"`
-	FilterIDs       []types.String     `tfsdk:"filter_ids"`
+	ProfileImageURL   types.String       `tfsdk:"profile_image_url"`
+	Description       types.String       `tfsdk:"description"`
+	Capabilities      *ModelCapabilities `tfsdk:"capabilities"`
+	Tags              []Tag              `tfsdk:"tags"`
+	ToolIDs           []types.String     `tfsdk:"tool_ids"`
+	FilterIDs         []types.String     `tfsdk:"filter_ids"`
+	DefaultFilterIDs  []types.String     `tfsdk:"default_filter_ids"`
+	ActionIDs         []types.String     `tfsdk:"action_ids"`
+	DefaultFeatureIDs []types.String     `tfsdk:"default_feature_ids"`
+	Knowledge         []KnowledgeItem    `tfsdk:"knowledge"`
 }
 
 type APIModelMeta struct {
-	ProfileImageURL string                `json:"profile_image_url,omitempty"`
-	Description     string                `json:"description,omitempty"`
-	Capabilities    *APIModelCapabilities `json:"capabilities,omitempty"`
-	Tags            []APITag              `json:"tags,omitempty"`
-	FilterIDs       []string              `json:"filterIds,omitempty"`
+	ProfileImageURL   string                `json:"profile_image_url,omitempty"`
+	Description       string                `json:"description,omitempty"`
+	Capabilities      *APIModelCapabilities `json:"capabilities,omitempty"`
+	Tags              []APITag              `json:"tags,omitempty"`
+	ToolIDs           []string              `json:"toolIds,omitempty"`
+	FilterIDs         []string              `json:"filterIds,omitempty"`
+	DefaultFilterIDs  []string              `json:"defaultFilterIds,omitempty"`
+	ActionIDs         []string              `json:"actionIds,omitempty"`
+	DefaultFeatureIDs []string              `json:"defaultFeatureIds,omitempty"`
+	Knowledge         []APIKnowledgeItem    `json:"knowledge,omitempty"`
 }
 
 type ModelCapabilities struct {
-	Vision    types.Bool `tfsdk:"vision"`
-	Usage     types.Bool `tfsdk:"usage"`
-	Citations types.Bool `tfsdk:"citations"`
+	Vision          types.Bool `tfsdk:"vision"`
+	Usage           types.Bool `tfsdk:"usage"`
+	Citations       types.Bool `tfsdk:"citations"`
+	WebSearch       types.Bool `tfsdk:"web_search"`
+	ImageGeneration types.Bool `tfsdk:"image_generation"`
+	CodeInterpreter types.Bool `tfsdk:"code_interpreter"`
 }
 
+// APIModelCapabilities represents the API model capabilities
+// The feature toggles are only sent when set; OpenWebUI enables a feature
+// when its key is missing.
 type APIModelCapabilities struct {
-	Vision    bool `json:"vision,omitempty"`
-	Usage     bool `json:"usage,omitempty"`
-	Citations bool `json:"citations,omitempty"`
+	Vision          bool  `json:"vision,omitempty"`
+	Usage           bool  `json:"usage,omitempty"`
+	Citations       bool  `json:"citations,omitempty"`
+	WebSearch       *bool `json:"web_search,omitempty"`
+	ImageGeneration *bool `json:"image_generation,omitempty"`
+	CodeInterpreter *bool `json:"code_interpreter,omitempty"`
 }
 
 type Tag struct {
@@ -113,6 +223,20 @@ type APITag struct {
 	Name string `json:"name,omitempty"`
 }
 
+// KnowledgeItem is a knowledge base or file the model retrieves from
+// Type is "collection" for knowledge bases and "file" for single files.
+type KnowledgeItem struct {
+	ID   types.String `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+type APIKnowledgeItem struct {
+	ID   string `json:"id"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
 type AccessControl struct {
 	Read  *AccessGroup `tfsdk:"read"`
 	Write *AccessGroup `tfsdk:"write"`
@@ -129,8 +253,8 @@ type AccessGroup struct {
 }
 
 type APIAccessGroup struct {
-	GroupIDs []string `json:"group_ids,omitempty"`
-	UserIDs  []string `json:"user_ids,omitempty"`
+	GroupIDs []string `json:"group_ids"`
+	UserIDs  []string `json:"user_ids"`
 }
 
 // Helper function to convert API model to Terraform model
@@ -174,9 +298,19 @@ func APIToModel(apiModel *APIModel) *Model {
 		if apiModel.Params.MinP != 0 {
 			model.Params.MinP = types.Float64Value(apiModel.Params.MinP)
 		}
-		if apiModel.Params.FrequencyPenalty != 0 {
-			model.Params.FrequencyPenalty = types.Int64Value(apiModel.Params.FrequencyPenalty)
-		}
+		model.Params.FrequencyPenalty = types.Float64PointerValue(apiModel.Params.FrequencyPenalty)
+		model.Params.PresencePenalty = types.Float64PointerValue(apiModel.Params.PresencePenalty)
+		model.Params.RepeatPenalty = types.Float64PointerValue(apiModel.Params.RepeatPenalty)
+		model.Params.Mirostat = types.Int64PointerValue(apiModel.Params.Mirostat)
+		model.Params.MirostatEta = types.Float64PointerValue(apiModel.Params.MirostatEta)
+		model.Params.MirostatTau = types.Float64PointerValue(apiModel.Params.MirostatTau)
+		model.Params.TfsZ = types.Float64PointerValue(apiModel.Params.TfsZ)
+		model.Params.Stop = stringsToValues(apiModel.Params.Stop)
+		model.Params.NumThread = types.Int64PointerValue(apiModel.Params.NumThread)
+		model.Params.NumGPU = types.Int64PointerValue(apiModel.Params.NumGPU)
+		model.Params.UseMmap = types.BoolPointerValue(apiModel.Params.UseMmap)
+		model.Params.UseMlock = types.BoolPointerValue(apiModel.Params.UseMlock)
+		model.Params.KeepAlive = types.StringPointerValue(apiModel.Params.KeepAlive)
 		if apiModel.Params.RepeatLastN != 0 {
 			model.Params.RepeatLastN = types.Int64Value(apiModel.Params.RepeatLastN)
 		}
@@ -194,6 +328,14 @@ func APIToModel(apiModel *APIModel) *Model {
 		}
 	}
 
+	// Unknown params are always reported, as an empty object if there are none
+	model.ExtraParams = jsontypes.NewNormalizedValue("{}")
+	if apiModel.Params != nil && len(apiModel.Params.Extra) > 0 {
+		if extra, err := json.Marshal(apiModel.Params.Extra); err == nil {
+			model.ExtraParams = jsontypes.NewNormalizedValue(string(extra))
+		}
+	}
+
 	if apiModel.Meta != nil {
 		model.Meta = &ModelMeta{}
 		if apiModel.Meta.ProfileImageURL != "" {
@@ -205,9 +347,12 @@ func APIToModel(apiModel *APIModel) *Model {
 
 		if apiModel.Meta.Capabilities != nil {
 			model.Meta.Capabilities = &ModelCapabilities{
-				Vision:    types.BoolValue(apiModel.Meta.Capabilities.Vision),
-				Usage:     types.BoolValue(apiModel.Meta.Capabilities.Usage),
-				Citations: types.BoolValue(apiModel.Meta.Capabilities.Citations),
+				Vision:          types.BoolValue(apiModel.Meta.Capabilities.Vision),
+				Usage:           types.BoolValue(apiModel.Meta.Capabilities.Usage),
+				Citations:       types.BoolValue(apiModel.Meta.Capabilities.Citations),
+				WebSearch:       types.BoolPointerValue(apiModel.Meta.Capabilities.WebSearch),
+				ImageGeneration: types.BoolPointerValue(apiModel.Meta.Capabilities.ImageGeneration),
+				CodeInterpreter: types.BoolPointerValue(apiModel.Meta.Capabilities.CodeInterpreter),
 			}
 		}
 
@@ -220,44 +365,273 @@ func APIToModel(apiModel *APIModel) *Model {
 			}
 		}
 
-		if len(apiModel.Meta.FilterIDs) > 0 {
-			model.Meta.FilterIDs = make([]types.String, len(apiModel.Meta.FilterIDs))
-			for i, id := range apiModel.Meta.FilterIDs {
-				model.Meta.FilterIDs[i] = types.StringValue(id)
+		model.Meta.ToolIDs = stringsToValues(apiModel.Meta.ToolIDs)
+		model.Meta.FilterIDs = stringsToValues(apiModel.Meta.FilterIDs)
+		model.Meta.DefaultFilterIDs = stringsToValues(apiModel.Meta.DefaultFilterIDs)
+		model.Meta.ActionIDs = stringsToValues(apiModel.Meta.ActionIDs)
+		model.Meta.DefaultFeatureIDs = stringsToValues(apiModel.Meta.DefaultFeatureIDs)
+
+		if len(apiModel.Meta.Knowledge) > 0 {
+			model.Meta.Knowledge = make([]KnowledgeItem, len(apiModel.Meta.Knowledge))
+			for i, item := range apiModel.Meta.Knowledge {
+				model.Meta.Knowledge[i] = KnowledgeItem{
+					ID:   types.StringValue(item.ID),
+					Type: types.StringValue(item.Type),
+					Name: types.StringNull(),
+				}
+				if item.Type == "" {
+					model.Meta.Knowledge[i].Type = types.StringValue("collection")
+				}
+				if item.Name != "" {
+					model.Meta.Knowledge[i].Name = types.StringValue(item.Name)
+				}
 			}
 		}
 	}
 
-	if apiModel.AccessControl != nil {
-		model.AccessControl = &AccessControl{}
-		if apiModel.AccessControl.Read != nil {
-			model.AccessControl.Read = &AccessGroup{
-				GroupIDs: make([]types.String, len(apiModel.AccessControl.Read.GroupIDs)),
-				UserIDs:  make([]types.String, len(apiModel.AccessControl.Read.UserIDs)),
-			}
-			for i, id := range apiModel.AccessControl.Read.GroupIDs {
-				model.AccessControl.Read.GroupIDs[i] = types.StringValue(id)
-			}
-			for i, id := range apiModel.AccessControl.Read.UserIDs {
-				model.AccessControl.Read.UserIDs[i] = types.StringValue(id)
+	model.AccessControl = APIToAccessControl(apiModel.AccessControl)
+	model.IsPrivate = types.BoolValue(apiModel.AccessControl != nil)
+
+	return model
+}
+
+// ModelToAPI converts a Terraform model to the API model
+func ModelToAPI(model *Model) *APIModel {
+	apiModel := &APIModel{
+		ID:          model.ID.ValueString(),
+		BaseModelID: model.BaseModelID.ValueString(),
+		Name:        model.Name.ValueString(),
+		IsActive:    model.IsActive.ValueBool(),
+	}
+
+	// Handle Params
+	if model.Params != nil {
+		apiModel.Params = &APIModelParams{}
+		if !model.Params.System.IsNull() {
+			apiModel.Params.System = model.Params.System.ValueString()
+		}
+		if !model.Params.StreamResponse.IsNull() {
+			apiModel.Params.StreamResponse = model.Params.StreamResponse.ValueBoolPointer()
+		}
+		if !model.Params.Temperature.IsNull() {
+			apiModel.Params.Temperature = model.Params.Temperature.ValueFloat64()
+		}
+		if !model.Params.ReasoningEffort.IsNull() {
+			apiModel.Params.ReasoningEffort = model.Params.ReasoningEffort.ValueString()
+		}
+		if !model.Params.TopP.IsNull() {
+			apiModel.Params.TopP = model.Params.TopP.ValueFloat64()
+		}
+		if !model.Params.MaxTokens.IsNull() {
+			apiModel.Params.MaxTokens = model.Params.MaxTokens.ValueInt64()
+		}
+		if !model.Params.Seed.IsNull() {
+			apiModel.Params.Seed = model.Params.Seed.ValueInt64()
+		}
+		if !model.Params.TopK.IsNull() {
+			apiModel.Params.TopK = model.Params.TopK.ValueInt64()
+		}
+		if !model.Params.MinP.IsNull() {
+			apiModel.Params.MinP = model.Params.MinP.ValueFloat64()
+		}
+		apiModel.Params.FrequencyPenalty = model.Params.FrequencyPenalty.ValueFloat64Pointer()
+		apiModel.Params.PresencePenalty = model.Params.PresencePenalty.ValueFloat64Pointer()
+		apiModel.Params.RepeatPenalty = model.Params.RepeatPenalty.ValueFloat64Pointer()
+		if !model.Params.RepeatLastN.IsNull() {
+			apiModel.Params.RepeatLastN = model.Params.RepeatLastN.ValueInt64()
+		}
+		if !model.Params.NumCtx.IsNull() {
+			apiModel.Params.NumCtx = model.Params.NumCtx.ValueInt64()
+		}
+		if !model.Params.NumBatch.IsNull() {
+			apiModel.Params.NumBatch = model.Params.NumBatch.ValueInt64()
+		}
+		if !model.Params.NumKeep.IsNull() {
+			apiModel.Params.NumKeep = model.Params.NumKeep.ValueInt64()
+		}
+		if !model.Params.FunctionCalling.IsNull() {
+			apiModel.Params.FunctionCalling = model.Params.FunctionCalling.ValueStringPointer()
+		}
+		apiModel.Params.Stop = valuesToStrings(model.Params.Stop)
+		apiModel.Params.Mirostat = model.Params.Mirostat.ValueInt64Pointer()
+		apiModel.Params.MirostatEta = model.Params.MirostatEta.ValueFloat64Pointer()
+		apiModel.Params.MirostatTau = model.Params.MirostatTau.ValueFloat64Pointer()
+		apiModel.Params.TfsZ = model.Params.TfsZ.ValueFloat64Pointer()
+		apiModel.Params.NumThread = model.Params.NumThread.ValueInt64Pointer()
+		apiModel.Params.NumGPU = model.Params.NumGPU.ValueInt64Pointer()
+		apiModel.Params.UseMmap = model.Params.UseMmap.ValueBoolPointer()
+		apiModel.Params.UseMlock = model.Params.UseMlock.ValueBoolPointer()
+		apiModel.Params.KeepAlive = model.Params.KeepAlive.ValueStringPointer()
+	}
+
+	// Handle Meta
+	if model.Meta != nil {
+		apiModel.Meta = &APIModelMeta{}
+		if !model.Meta.ProfileImageURL.IsNull() {
+			apiModel.Meta.ProfileImageURL = model.Meta.ProfileImageURL.ValueString()
+		}
+		if !model.Meta.Description.IsNull() {
+			apiModel.Meta.Description = model.Meta.Description.ValueString()
+		}
+
+		if model.Meta.Capabilities != nil {
+			apiModel.Meta.Capabilities = &APIModelCapabilities{
+				Vision:          model.Meta.Capabilities.Vision.ValueBool(),
+				Usage:           model.Meta.Capabilities.Usage.ValueBool(),
+				Citations:       model.Meta.Capabilities.Citations.ValueBool(),
+				WebSearch:       knownBoolPointer(model.Meta.Capabilities.WebSearch),
+				ImageGeneration: knownBoolPointer(model.Meta.Capabilities.ImageGeneration),
+				CodeInterpreter: knownBoolPointer(model.Meta.Capabilities.CodeInterpreter),
 			}
 		}
-		if apiModel.AccessControl.Write != nil {
-			model.AccessControl.Write = &AccessGroup{
-				GroupIDs: make([]types.String, len(apiModel.AccessControl.Write.GroupIDs)),
-				UserIDs:  make([]types.String, len(apiModel.AccessControl.Write.UserIDs)),
+
+		if len(model.Meta.Tags) > 0 {
+			apiModel.Meta.Tags = make([]APITag, len(model.Meta.Tags))
+			for i, tag := range model.Meta.Tags {
+				if !tag.Name.IsNull() {
+					apiModel.Meta.Tags[i] = APITag{
+						Name: tag.Name.ValueString(),
+					}
+				}
 			}
-			for i, id := range apiModel.AccessControl.Write.GroupIDs {
-				model.AccessControl.Write.GroupIDs[i] = types.StringValue(id)
+		}
+
+		apiModel.Meta.ToolIDs = valuesToStrings(model.Meta.ToolIDs)
+		apiModel.Meta.FilterIDs = valuesToStrings(model.Meta.FilterIDs)
+		apiModel.Meta.DefaultFilterIDs = valuesToStrings(model.Meta.DefaultFilterIDs)
+		apiModel.Meta.ActionIDs = valuesToStrings(model.Meta.ActionIDs)
+		apiModel.Meta.DefaultFeatureIDs = valuesToStrings(model.Meta.DefaultFeatureIDs)
+
+		if len(model.Meta.Knowledge) > 0 {
+			apiModel.Meta.Knowledge = make([]APIKnowledgeItem, len(model.Meta.Knowledge))
+			for i, item := range model.Meta.Knowledge {
+				apiModel.Meta.Knowledge[i] = APIKnowledgeItem{
+					ID:   item.ID.ValueString(),
+					Type: item.Type.ValueString(),
+					Name: item.Name.ValueString(),
+				}
 			}
-			for i, id := range apiModel.AccessControl.Write.UserIDs {
-				model.AccessControl.Write.UserIDs[i] = types.StringValue(id)
+		}
+	}
+
+	// Handle AccessControl
+	apiModel.AccessControl = AccessControlToAPI(model.AccessControl)
+
+	// Handle extra params, which are merged with the typed params
+	if !model.ExtraParams.IsNull() && !model.ExtraParams.IsUnknown() {
+		var extra map[string]interface{}
+		if err := json.Unmarshal([]byte(model.ExtraParams.ValueString()), &extra); err == nil && len(extra) > 0 {
+			if apiModel.Params == nil {
+				apiModel.Params = &APIModelParams{}
 			}
+			apiModel.Params.Extra = extra
 		}
-		model.IsPrivate = types.BoolValue(true)
-	} else {
-		model.IsPrivate = types.BoolValue(false)
 	}
 
-	return model
+	return apiModel
+}
+
+// knownBoolPointer returns nil for null and unknown values, so that values
+// left to OpenWebUI are not sent.
+func knownBoolPointer(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueBoolPointer()
+}
+
+// stringsToValues converts API IDs to Terraform values. An empty list yields
+// nil so that unset attributes stay null.
+func stringsToValues(ids []string) []types.String {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]types.String, len(ids))
+	for i, id := range ids {
+		values[i] = types.StringValue(id)
+	}
+	return values
+}
+
+// valuesToStrings converts Terraform values to API IDs, skipping null values.
+func valuesToStrings(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		if !value.IsNull() {
+			ids = append(ids, value.ValueString())
+		}
+	}
+	return ids
+}
+
+// APIToAccessControl converts an API access control to the Terraform model.
+// A nil input (public access) yields nil. ID lists are always non-nil so that
+// an explicit empty list stays empty instead of turning null.
+func APIToAccessControl(apiAccessControl *APIAccessControl) *AccessControl {
+	if apiAccessControl == nil {
+		return nil
+	}
+
+	return &AccessControl{
+		Read:  apiToAccessGroup(apiAccessControl.Read),
+		Write: apiToAccessGroup(apiAccessControl.Write),
+	}
+}
+
+// AccessControlToAPI converts a Terraform access control to the API model.
+// A nil input (public access) yields nil.
+func AccessControlToAPI(accessControl *AccessControl) *APIAccessControl {
+	if accessControl == nil {
+		return nil
+	}
+
+	return &APIAccessControl{
+		Read:  accessGroupToAPI(accessControl.Read),
+		Write: accessGroupToAPI(accessControl.Write),
+	}
+}
+
+func apiToAccessGroup(apiGroup *APIAccessGroup) *AccessGroup {
+	if apiGroup == nil {
+		return nil
+	}
+
+	group := &AccessGroup{
+		GroupIDs: make([]types.String, len(apiGroup.GroupIDs)),
+		UserIDs:  make([]types.String, len(apiGroup.UserIDs)),
+	}
+	for i, id := range apiGroup.GroupIDs {
+		group.GroupIDs[i] = types.StringValue(id)
+	}
+	for i, id := range apiGroup.UserIDs {
+		group.UserIDs[i] = types.StringValue(id)
+	}
+	return group
+}
+
+func accessGroupToAPI(group *AccessGroup) *APIAccessGroup {
+	if group == nil {
+		return nil
+	}
+
+	apiGroup := &APIAccessGroup{
+		GroupIDs: make([]string, 0, len(group.GroupIDs)),
+		UserIDs:  make([]string, 0, len(group.UserIDs)),
+	}
+	for _, id := range group.GroupIDs {
+		if !id.IsNull() {
+			apiGroup.GroupIDs = append(apiGroup.GroupIDs, id.ValueString())
+		}
+	}
+	for _, id := range group.UserIDs {
+		if !id.IsNull() {
+			apiGroup.UserIDs = append(apiGroup.UserIDs, id.ValueString())
+		}
+	}
+	return apiGroup
 }
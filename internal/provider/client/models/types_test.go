@@ -0,0 +1,88 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAPIModelParamsExtra(t *testing.T) {
+	input := `{"temperature":0.5,"frequency_penalty":0.2,"stop":["END"],"custom_params":{"foo":"bar"},"think":true}`
+
+	var params APIModelParams
+	if err := json.Unmarshal([]byte(input), &params); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if params.Temperature != 0.5 {
+		t.Errorf("Expected temperature 0.5, got %v", params.Temperature)
+	}
+	if params.FrequencyPenalty == nil || *params.FrequencyPenalty != 0.2 {
+		t.Errorf("Expected frequency_penalty 0.2, got %v", params.FrequencyPenalty)
+	}
+	if len(params.Extra) != 2 || params.Extra["think"] != true {
+		t.Errorf("Expected custom_params and think in extra params, got %v", params.Extra)
+	}
+
+	output, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var expected, actual map[string]interface{}
+	json.Unmarshal([]byte(input), &expected)
+	json.Unmarshal(output, &actual)
+	if len(actual) != len(expected) {
+		t.Errorf("Expected %s, got %s", input, string(output))
+	}
+	for key := range expected {
+		if _, ok := actual[key]; !ok {
+			t.Errorf("Expected key %s in %s", key, string(output))
+		}
+	}
+}
+
+func TestAPIModelParamsTypedWins(t *testing.T) {
+	params := APIModelParams{
+		Temperature: 0.7,
+		Extra:       map[string]interface{}{"temperature": 0.1, "think": false},
+	}
+
+	output, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var actual map[string]interface{}
+	json.Unmarshal(output, &actual)
+	if actual["temperature"] != 0.7 {
+		t.Errorf("Expected typed temperature 0.7, got %v", actual["temperature"])
+	}
+	if actual["think"] != false {
+		t.Errorf("Expected extra param think, got %v", actual["think"])
+	}
+}
+
+func TestAccessControlEmptyLists(t *testing.T) {
+	input := `{"read":{"group_ids":[],"user_ids":[]},"write":{"group_ids":["g1"],"user_ids":[]}}`
+
+	var apiAccessControl APIAccessControl
+	if err := json.Unmarshal([]byte(input), &apiAccessControl); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	accessControl := APIToAccessControl(&apiAccessControl)
+	if accessControl.Read.GroupIDs == nil || accessControl.Read.UserIDs == nil || accessControl.Write.UserIDs == nil {
+		t.Errorf("Expected empty lists to stay empty, got %+v", accessControl)
+	}
+
+	output, err := json.Marshal(AccessControlToAPI(accessControl))
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if string(output) != input {
+		t.Errorf("Expected %s, got %s", input, string(output))
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package pipelines
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+const (
+	basePath   = "/api/v1/pipelines"
+	listPath   = basePath + "/list"
+	addPath    = basePath + "/add"
+	uploadPath = basePath + "/upload"
+	deletePath = basePath + "/delete"
+)
+
+// ErrNotFound is returned when the pipelines server does not know a pipeline
+var ErrNotFound = errors.New("pipeline not found")
+
+// Client implements the pipelines operations. Pipelines are installed on the
+// pipelines servers behind OpenAI API connections, selected by their index.
+type Client struct {
+	endpoint string
+	token    string
+}
+
+// NewClient creates a new pipelines client
+func NewClient(endpoint, token string) *Client {
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+	}
+}
+
+// ListConnections lists the connections that are served by a pipelines server
+func (c *Client) ListConnections() ([]Connection, error) {
+	var result struct {
+		Data []Connection `json:"data"`
+	}
+	if err := c.do("GET", fmt.Sprintf("%s%s", c.endpoint, listPath), nil, "", &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// List lists the pipelines installed on the pipelines server of a connection
+func (c *Client) List(urlIdx int64) ([]Pipeline, error) {
+	var result struct {
+		Data []Pipeline `json:"data"`
+	}
+	if err := c.do("GET", fmt.Sprintf("%s%s/?urlIdx=%d", c.endpoint, basePath, urlIdx), nil, "", &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// Get gets an installed pipeline by ID. It returns nil if the pipeline is not installed.
+func (c *Client) Get(id string, urlIdx int64) (*Pipeline, error) {
+	pipelines, err := c.List(urlIdx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pipeline := range pipelines {
+		if pipeline.ID == id {
+			return &pipeline, nil
+		}
+	}
+	return nil, nil
+}
+
+// Add installs a pipeline from a URL. The pipelines server downloads it and
+// derives the pipeline ID from the file name.
+func (c *Client) Add(url string, urlIdx int64) error {
+	payload, err := json.Marshal(AddForm{URL: url, URLIdx: urlIdx})
+	if err != nil {
+		return fmt.Errorf("error marshaling pipeline: %v", err)
+	}
+
+	log.Printf("[DEBUG] AddPipeline request payload: %s", string(payload))
+
+	return c.do("POST", fmt.Sprintf("%s%s", c.endpoint, addPath), bytes.NewBuffer(payload), "application/json", nil)
+}
+
+// Upload installs a pipeline from the content of a Python file. The pipeline
+// ID is derived from the file name.
+func (c *Client) Upload(filename string, content []byte, urlIdx int64) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("urlIdx", strconv.FormatInt(urlIdx, 10)); err != nil {
+		return fmt.Errorf("error writing urlIdx field: %v", err)
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("error creating form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return fmt.Errorf("error writing form file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error closing form: %v", err)
+	}
+
+	return c.do("POST", fmt.Sprintf("%s%s", c.endpoint, uploadPath), body, writer.FormDataContentType(), nil)
+}
+
+// Delete removes a pipeline from the pipelines server of a connection
+func (c *Client) Delete(id string, urlIdx int64) error {
+	payload, err := json.Marshal(DeleteForm{ID: id, URLIdx: urlIdx})
+	if err != nil {
+		return fmt.Errorf("error marshaling pipeline: %v", err)
+	}
+
+	return c.do("DELETE", fmt.Sprintf("%s%s", c.endpoint, deletePath), bytes.NewBuffer(payload), "application/json", nil)
+}
+
+// GetValves gets the current valve values of a pipeline
+func (c *Client) GetValves(id string, urlIdx int64) (map[string]interface{}, error) {
+	var valves map[string]interface{}
+	err := c.do("GET", fmt.Sprintf("%s%s/%s/valves?urlIdx=%d", c.endpoint, basePath, id, urlIdx), nil, "", &valves)
+	return valves, err
+}
+
+// GetValvesSpec gets the JSON schema of the valves of a pipeline
+func (c *Client) GetValvesSpec(id string, urlIdx int64) (map[string]interface{}, error) {
+	var spec map[string]interface{}
+	err := c.do("GET", fmt.Sprintf("%s%s/%s/valves/spec?urlIdx=%d", c.endpoint, basePath, id, urlIdx), nil, "", &spec)
+	return spec, err
+}
+
+// UpdateValves replaces the valve values of a pipeline
+func (c *Client) UpdateValves(id string, urlIdx int64, values map[string]interface{}) (map[string]interface{}, error) {
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling valves: %v", err)
+	}
+
+	var valves map[string]interface{}
+	err = c.do("POST", fmt.Sprintf("%s%s/%s/valves/update?urlIdx=%d", c.endpoint, basePath, id, urlIdx), bytes.NewBuffer(payload), "application/json", &valves)
+	return valves, err
+}
+
+// do sends a request and decodes the response into result, if given. Responses
+// are not logged as they may contain valve secrets.
+func (c *Client) do(method, url string, body *bytes.Buffer, contentType string, result interface{}) error {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, string(bodyBytes))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(bodyBytes, result); err != nil {
+			return fmt.Errorf("error decoding response: %v", err)
+		}
+	}
+
+	return nil
+}
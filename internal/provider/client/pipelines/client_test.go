@@ -0,0 +1,109 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package pipelines
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pipelines/add" {
+			t.Errorf("Expected path '/api/v1/pipelines/add', got %s", r.URL.Path)
+		}
+		if r.Method != "POST" {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+
+		var form AddForm
+		if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if form.URL != "https://example.com/rate_limit.py" || form.URLIdx != 1 {
+			t.Errorf("Unexpected request %+v", form)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.Add("https://example.com/rate_limit.py", 1); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pipelines/upload" {
+			t.Errorf("Expected path '/api/v1/pipelines/upload', got %s", r.URL.Path)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Failed to read form file: %v", err)
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		if header.Filename != "rate_limit.py" {
+			t.Errorf("Expected filename 'rate_limit.py', got '%s'", header.Filename)
+		}
+		if string(content) != "class Pipeline: pass" {
+			t.Errorf("Unexpected content '%s'", string(content))
+		}
+		if r.FormValue("urlIdx") != "2" {
+			t.Errorf("Expected urlIdx '2', got '%s'", r.FormValue("urlIdx"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.Upload("rate_limit.py", []byte("class Pipeline: pass"), 2); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pipelines/" {
+			t.Errorf("Expected path '/api/v1/pipelines/', got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("urlIdx") != "0" {
+			t.Errorf("Expected urlIdx '0', got '%s'", r.URL.Query().Get("urlIdx"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []Pipeline{{ID: "rate_limit", Name: "Rate Limit", Type: "filter", Valves: true}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	pipeline, err := client.Get("rate_limit", 0)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if pipeline == nil || pipeline.Type != "filter" {
+		t.Errorf("Expected filter pipeline, got %+v", pipeline)
+	}
+
+	pipeline, err = client.Get("missing", 0)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if pipeline != nil {
+		t.Errorf("Expected nil for a pipeline that is not installed, got %+v", pipeline)
+	}
+}
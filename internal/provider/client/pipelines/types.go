@@ -0,0 +1,30 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package pipelines
+
+// Connection represents an OpenAI API connection served by a pipelines server
+type Connection struct {
+	URL string `json:"url"`
+	Idx int64  `json:"idx"`
+}
+
+// Pipeline represents a pipeline installed on a pipelines server
+type Pipeline struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type,omitempty"`
+	Valves bool   `json:"valves,omitempty"`
+}
+
+// AddForm represents the request to install a pipeline from a URL
+type AddForm struct {
+	URL    string `json:"url"`
+	URLIdx int64  `json:"urlIdx"`
+}
+
+// DeleteForm represents the request to remove a pipeline
+type DeleteForm struct {
+	ID     string `json:"id"`
+	URLIdx int64  `json:"urlIdx"`
+}
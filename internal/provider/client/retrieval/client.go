@@ -0,0 +1,86 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package retrieval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	basePath           = "/api/v1/retrieval"
+	processWebPath     = basePath + "/process/web"
+	processTextPath    = basePath + "/process/text"
+	processYoutubePath = basePath + "/process/youtube"
+)
+
+// Client implements the retrieval operations
+type Client struct {
+	endpoint string
+	token    string
+}
+
+// NewClient creates a new retrieval client
+func NewClient(endpoint, token string) *Client {
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+	}
+}
+
+// ProcessWeb fetches a web page and indexes its content
+func (c *Client) ProcessWeb(form *ProcessURLForm) (*ProcessResponse, error) {
+	return c.process(processWebPath, form)
+}
+
+// ProcessYoutube fetches the transcript of a YouTube video and indexes it
+func (c *Client) ProcessYoutube(form *ProcessURLForm) (*ProcessResponse, error) {
+	return c.process(processYoutubePath, form)
+}
+
+// ProcessText indexes raw text
+func (c *Client) ProcessText(form *ProcessTextForm) (*ProcessResponse, error) {
+	return c.process(processTextPath, form)
+}
+
+// process posts a form to one of the process endpoints
+func (c *Client) process(path string, form interface{}) (*ProcessResponse, error) {
+	body, err := json.Marshal(form)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, path), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result ProcessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if !result.Status {
+		return nil, fmt.Errorf("processing was not successful")
+	}
+
+	return &result, nil
+}
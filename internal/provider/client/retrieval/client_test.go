@@ -0,0 +1,62 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package retrieval
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProcessWeb(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/retrieval/process/web" {
+			t.Errorf("Expected path '/api/v1/retrieval/process/web', got %s", r.URL.Path)
+		}
+
+		var form ProcessURLForm
+		if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if form.URL != "https://example.com/policy" {
+			t.Errorf("Expected URL 'https://example.com/policy', got '%s'", form.URL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status": true, "collection_name": "abc", "filename": "https://example.com/policy",
+			"file": {"data": {"content": "Policy text"}, "meta": {"name": "https://example.com/policy"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.ProcessWeb(&ProcessURLForm{URL: "https://example.com/policy"})
+	if err != nil {
+		t.Fatalf("ProcessWeb returned error: %v", err)
+	}
+	if result.ExtractedContent() != "Policy text" {
+		t.Errorf("Expected content 'Policy text', got '%s'", result.ExtractedContent())
+	}
+}
+
+func TestProcessText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/retrieval/process/text" {
+			t.Errorf("Expected path '/api/v1/retrieval/process/text', got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&ProcessResponse{Status: true, CollectionName: "abc", Content: "Raw text"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.ProcessText(&ProcessTextForm{Name: "notes", Content: "Raw text"})
+	if err != nil {
+		t.Fatalf("ProcessText returned error: %v", err)
+	}
+	if result.ExtractedContent() != "Raw text" {
+		t.Errorf("Expected content 'Raw text', got '%s'", result.ExtractedContent())
+	}
+}
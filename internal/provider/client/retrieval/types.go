@@ -0,0 +1,43 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package retrieval
+
+// ProcessURLForm represents the form data for processing a web page or YouTube video
+type ProcessURLForm struct {
+	URL            string `json:"url"`
+	CollectionName string `json:"collection_name,omitempty"`
+}
+
+// ProcessTextForm represents the form data for processing raw text
+type ProcessTextForm struct {
+	Name           string `json:"name"`
+	Content        string `json:"content"`
+	CollectionName string `json:"collection_name,omitempty"`
+}
+
+// ProcessResponse represents the API response of the process endpoints
+// Text processing returns the content directly, web and YouTube processing
+// return it in File.
+type ProcessResponse struct {
+	Status         bool           `json:"status"`
+	CollectionName string         `json:"collection_name"`
+	Content        string         `json:"content,omitempty"`
+	File           *ProcessedFile `json:"file,omitempty"`
+}
+
+// ProcessedFile holds the content extracted from a web page or YouTube video
+type ProcessedFile struct {
+	Data struct {
+		Content string `json:"content"`
+	} `json:"data"`
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ExtractedContent returns the text extracted by any of the process endpoints
+func (p *ProcessResponse) ExtractedContent() string {
+	if p.File != nil {
+		return p.File.Data.Content
+	}
+	return p.Content
+}
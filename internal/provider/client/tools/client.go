@@ -6,6 +6,7 @@ package tools
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -18,6 +19,12 @@ const (
 	listPath   = basePath + "/"
 )
 
+// ErrNotFound is returned by the valves operations when the tool does not exist
+var ErrNotFound = errors.New("tool not found")
+
+// notFoundDetail is the error OpenWebUI returns, with status 401, for unknown IDs
+const notFoundDetail = "We could not find what you're looking for"
+
 // Client implements the tools operations
 type Client struct {
 	endpoint string
@@ -39,6 +46,12 @@ func (c *Client) Create(tool *APITool) (*APITool, error) {
 		return nil, fmt.Errorf("error marshaling tool: %v", err)
 	}
 
+	return c.CreateRaw(payload)
+}
+
+// CreateRaw creates a new tool from its JSON representation, e.g. an item
+// of a tools export, including fields that APITool does not declare
+func (c *Client) CreateRaw(payload json.RawMessage) (*APITool, error) {
 	log.Printf("[DEBUG] CreateTool request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", c.endpoint, createPath), bytes.NewBuffer(payload))
@@ -137,6 +150,12 @@ func (c *Client) Update(id string, tool *APITool) (*APITool, error) {
 		return nil, fmt.Errorf("error marshaling tool: %v", err)
 	}
 
+	return c.UpdateRaw(id, payload)
+}
+
+// UpdateRaw updates a tool from its JSON representation, e.g. an item
+// of a tools export, including fields that APITool does not declare
+func (c *Client) UpdateRaw(id string, payload json.RawMessage) (*APITool, error) {
 	log.Printf("[DEBUG] UpdateTool request payload: %s", string(payload))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/id/%s/update", c.endpoint, basePath, id), bytes.NewBuffer(payload))
@@ -192,3 +211,99 @@ func (c *Client) Delete(id string) error {
 
 	return nil
 }
+
+// GetValves gets the current valve values of a tool
+func (c *Client) GetValves(id string) (map[string]interface{}, error) {
+	return c.valvesRequest("GET", fmt.Sprintf("%s%s/id/%s/valves", c.endpoint, basePath, id), nil)
+}
+
+// GetValvesSpec gets the JSON schema of the valves of a tool. It returns
+// nil if the tool has no valves.
+func (c *Client) GetValvesSpec(id string) (map[string]interface{}, error) {
+	return c.valvesRequest("GET", fmt.Sprintf("%s%s/id/%s/valves/spec", c.endpoint, basePath, id), nil)
+}
+
+// UpdateValves replaces the valve values of a tool
+func (c *Client) UpdateValves(id string, values map[string]interface{}) (map[string]interface{}, error) {
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling valves: %v", err)
+	}
+
+	return c.valvesRequest("POST", fmt.Sprintf("%s%s/id/%s/valves/update", c.endpoint, basePath, id), payload)
+}
+
+// valvesRequest sends a request to one of the valves endpoints
+func (c *Client) valvesRequest(method, url string, payload []byte) (map[string]interface{}, error) {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound ||
+		(resp.StatusCode == http.StatusUnauthorized && bytes.Contains(bodyBytes, []byte(notFoundDetail))) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, string(bodyBytes))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var valves map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &valves); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return valves, nil
+}
+
+// LoadURL loads the source of a tool from a URL, e.g. a GitHub file or an
+// OpenWebUI community page. OpenWebUI fetches the URL, not the provider.
+func (c *Client) LoadURL(url string) (*APILoadedSource, error) {
+	payload, err := json.Marshal(map[string]string{"url": url})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling url: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/load/url", c.endpoint, basePath), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var source APILoadedSource
+	if err := json.Unmarshal(bodyBytes, &source); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return &source, nil
+}
@@ -98,7 +98,9 @@ func APIToTool(apiTool *APITool) *Tool {
 
 	// Handle Meta
 	if apiTool.Meta != nil {
-		tool.Meta = &ToolMeta{}
+		tool.Meta = &ToolMeta{
+			Manifest: types.MapNull(types.StringType),
+		}
 		if apiTool.Meta.Description != "" {
 			tool.Meta.Description = types.StringValue(apiTool.Meta.Description)
 		}
@@ -146,3 +148,9 @@ func APIToTool(apiTool *APITool) *Tool {
 
 	return tool
 }
+
+// APILoadedSource represents the tool source loaded from a URL
+type APILoadedSource struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
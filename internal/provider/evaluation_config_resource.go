@@ -0,0 +1,266 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/evaluations"
+)
+
+var (
+	_ resource.Resource                = &EvaluationConfigResource{}
+	_ resource.ResourceWithImportState = &EvaluationConfigResource{}
+)
+
+func NewEvaluationConfigResource() resource.Resource {
+	return &EvaluationConfigResource{}
+}
+
+type EvaluationConfigResource struct {
+	client *evaluations.Client
+}
+
+func (r *EvaluationConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evaluation_config"
+}
+
+func (r *EvaluationConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["evaluations"].(*evaluations.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *evaluations.Client, got: %T. Please report this issue to the provider developers.", clients["evaluations"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EvaluationConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the OpenWebUI evaluation arena configuration. This is a singleton resource with a fixed ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "Fixed identifier for the evaluation config (always 'evaluations').",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"enable_arena_models": schema.BoolAttribute{
+				Description: "Whether arena models are enabled for evaluations.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"arena_models": schema.ListNestedAttribute{
+				Description: "List of arena models used to compare models side by side.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the arena model.",
+							Required:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The display name of the arena model.",
+							Required:    true,
+						},
+						"meta": schema.SingleNestedAttribute{
+							Description: "Arena model metadata.",
+							Optional:    true,
+							Attributes: map[string]schema.Attribute{
+								"profile_image_url": schema.StringAttribute{
+									Description: "URL for the arena model's profile image.",
+									Optional:    true,
+								},
+								"description": schema.StringAttribute{
+									Description: "Description of the arena model.",
+									Optional:    true,
+								},
+								"model_ids": schema.ListAttribute{
+									Description: "IDs of the models the arena model picks from. All models are used when unset.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+								"filter_mode": schema.StringAttribute{
+									Description: "Whether model_ids lists the models to include or exclude. If set, must be one of: 'include', 'exclude'.",
+									Optional:    true,
+									Validators: []validator.String{
+										stringvalidator.OneOf("include", "exclude"),
+									},
+								},
+								"access_control": schema.SingleNestedAttribute{
+									Description: "Access control settings. The arena model is public when unset.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"read": schema.SingleNestedAttribute{
+											Description: "Read access settings.",
+											Optional:    true,
+											Attributes: map[string]schema.Attribute{
+												"group_ids": schema.ListAttribute{
+													Description: "List of group IDs with read access.",
+													Optional:    true,
+													ElementType: types.StringType,
+												},
+												"user_ids": schema.ListAttribute{
+													Description: "List of user IDs with read access.",
+													Optional:    true,
+													ElementType: types.StringType,
+												},
+											},
+										},
+										"write": schema.SingleNestedAttribute{
+											Description: "Write access settings.",
+											Optional:    true,
+											Attributes: map[string]schema.Attribute{
+												"group_ids": schema.ListAttribute{
+													Description: "List of group IDs with write access.",
+													Optional:    true,
+													ElementType: types.StringType,
+												},
+												"user_ids": schema.ListAttribute{
+													Description: "List of user IDs with write access.",
+													Optional:    true,
+													ElementType: types.StringType,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *EvaluationConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan evaluations.EvaluationConfig
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.UpdateConfig(evaluationConfigToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating evaluation config", err.Error())
+		return
+	}
+
+	// Convert API response back to Terraform model
+	state := evaluations.APIToEvaluationConfig(config)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EvaluationConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state evaluations.EvaluationConfig
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading evaluation config", err.Error())
+		return
+	}
+
+	// Convert API response to Terraform model
+	newState := evaluations.APIToEvaluationConfig(config)
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EvaluationConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan evaluations.EvaluationConfig
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.UpdateConfig(evaluationConfigToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating evaluation config", err.Error())
+		return
+	}
+
+	// Convert API response back to Terraform model
+	state := evaluations.APIToEvaluationConfig(config)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EvaluationConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to OpenWebUI defaults
+	apiConfig := &evaluations.APIEvaluationConfig{
+		EnableArenaModels: true,
+		ArenaModels:       []evaluations.APIArenaModel{},
+	}
+
+	_, err := r.client.UpdateConfig(apiConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting evaluation config", err.Error())
+		return
+	}
+}
+
+func (r *EvaluationConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID must be "evaluations"
+	if req.ID != "evaluations" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID must be 'evaluations', got: %s", req.ID),
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// evaluationConfigToAPI converts the Terraform evaluation config to the API model.
+func evaluationConfigToAPI(config *evaluations.EvaluationConfig) *evaluations.APIEvaluationConfig {
+	apiConfig := &evaluations.APIEvaluationConfig{
+		EnableArenaModels: config.EnableArenaModels.ValueBool(),
+		ArenaModels:       make([]evaluations.APIArenaModel, len(config.ArenaModels)),
+	}
+
+	for i, model := range config.ArenaModels {
+		apiConfig.ArenaModels[i] = evaluations.ArenaModelToAPI(&model)
+	}
+
+	return apiConfig
+}
@@ -0,0 +1,324 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &FileResource{}
+var _ resource.ResourceWithModifyPlan = &FileResource{}
+
+func NewFileResource() resource.Resource {
+	return &FileResource{}
+}
+
+// FileResource defines the resource implementation.
+type FileResource struct {
+	client *files.Client
+}
+
+// FileResourceModel describes the resource data model.
+type FileResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Source      types.String `tfsdk:"source"`
+	Content     types.String `tfsdk:"content"`
+	Filename    types.String `tfsdk:"filename"`
+	Metadata    types.Map    `tfsdk:"metadata"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	ContentType types.String `tfsdk:"content_type"`
+	Size        types.Int64  `tfsdk:"size"`
+	UserID      types.String `tfsdk:"user_id"`
+	CreatedAt   types.Int64  `tfsdk:"created_at"`
+}
+
+func (r *FileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a file to OpenWebUI so it can be referenced by ID, e.g. from models or chats. " +
+			"Files cannot be modified after upload: any change of content, filename or metadata uploads a new file and deletes the previous one.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "File identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path of the local file to upload. Exactly one of `source` or `content` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Inline content to upload. Requires `filename`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("filename")),
+				},
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "Name of the file in OpenWebUI. Defaults to the base name of `source`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Additional metadata stored with the file",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the uploaded content",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type detected by OpenWebUI",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size in bytes",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the user owning the file",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the upload",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to hash on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan FileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() || plan.Content.IsUnknown() {
+		return
+	}
+
+	content, diags := fileResourceContent(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hash := fileContentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state FileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changed content is uploaded as a new file
+	if state.ContentHash.ValueString() != hash {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+	}
+}
+
+func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, diags := fileResourceContent(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Filename.IsNull() || data.Filename.IsUnknown() {
+		data.Filename = types.StringValue(filepath.Base(data.Source.ValueString()))
+	}
+
+	metadata := make(map[string]string)
+	if !data.Metadata.IsNull() {
+		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Upload the file
+	file, err := r.client.UploadWithMetadata(data.Filename.ValueString(), content, metadata)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload file, got error: %s", err))
+		return
+	}
+
+	// Map response to model
+	data.ID = types.StringValue(file.ID)
+	data.ContentHash = types.StringValue(fileContentHash(content))
+	data.ContentType = types.StringValue(file.ContentType())
+	data.Size = types.Int64Value(file.Size())
+	data.UserID = types.StringValue(file.UserID)
+	data.CreatedAt = types.Int64Value(file.CreatedAt)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get file from API
+	file, err := r.client.Get(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file, got error: %s", err))
+		return
+	}
+
+	// The file was deleted outside of Terraform
+	if file == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Map response to model
+	data.Filename = types.StringValue(file.Filename)
+	data.ContentType = types.StringValue(file.ContentType())
+	data.Size = types.Int64Value(file.Size())
+	data.UserID = types.StringValue(file.UserID)
+	data.CreatedAt = types.Int64Value(file.CreatedAt)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only switching between source and content with identical bytes is
+	// updated in place; the uploaded file stays as is
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete file
+	if err := r.client.Delete(data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file, got error: %s", err))
+		return
+	}
+}
+
+// fileResourceContent returns the bytes to upload, read from source or taken
+// from the inline content.
+func fileResourceContent(data *FileResourceModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.Content.IsNull() {
+		return []byte(data.Content.ValueString()), diags
+	}
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("source"), "Unable to Read File", err.Error())
+		return nil, diags
+	}
+	return content, diags
+}
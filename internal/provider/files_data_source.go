@@ -0,0 +1,202 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &FilesDataSource{}
+
+func NewFilesDataSource() datasource.DataSource {
+	return &FilesDataSource{}
+}
+
+// FilesDataSource defines the data source implementation.
+type FilesDataSource struct {
+	client *files.Client
+}
+
+// FilesDataSourceModel describes the data source data model.
+type FilesDataSourceModel struct {
+	Filename types.String `tfsdk:"filename"`
+	IDs      types.List   `tfsdk:"ids"`
+	Files    types.List   `tfsdk:"files"`
+}
+
+// FileDataModel describes a file returned by the files data source.
+type FileDataModel struct {
+	ID            types.String `tfsdk:"id"`
+	Filename      types.String `tfsdk:"filename"`
+	UserID        types.String `tfsdk:"user_id"`
+	ExtractedHash types.String `tfsdk:"extracted_hash"`
+	ContentType   types.String `tfsdk:"content_type"`
+	Size          types.Int64  `tfsdk:"size"`
+	CreatedAt     types.Int64  `tfsdk:"created_at"`
+	UpdatedAt     types.Int64  `tfsdk:"updated_at"`
+}
+
+var fileDataAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"filename":       types.StringType,
+	"user_id":        types.StringType,
+	"extracted_hash": types.StringType,
+	"content_type":   types.StringType,
+	"size":           types.Int64Type,
+	"created_at":     types.Int64Type,
+	"updated_at":     types.Int64Type,
+}
+
+func (d *FilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_files"
+}
+
+func (d *FilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists uploaded OpenWebUI files, optionally filtered by filename",
+
+		Attributes: map[string]schema.Attribute{
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "Filename pattern to match, supporting `*` and `?` wildcards (e.g. `*.pdf`). Defaults to all files",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching files",
+			},
+			"files": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching files",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "File identifier",
+						},
+						"filename": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the file",
+						},
+						"user_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the user owning the file",
+						},
+						"extracted_hash": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA-256 hash of the text extracted from the file, not of the file itself",
+						},
+						"content_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "MIME type of the file",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size in bytes",
+						},
+						"created_at": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Timestamp of the upload",
+						},
+						"updated_at": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Timestamp of the last update",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FilesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pattern := "*"
+	if !data.Filename.IsNull() {
+		pattern = data.Filename.ValueString()
+	}
+
+	// Search files from API
+	result, err := d.client.Search(pattern)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search files, got error: %s", err))
+		return
+	}
+
+	ids := make([]string, 0, len(result))
+	fileModels := make([]FileDataModel, 0, len(result))
+	for _, file := range result {
+		extractedHash := types.StringNull()
+		if file.Hash != "" {
+			extractedHash = types.StringValue(file.Hash)
+		}
+
+		ids = append(ids, file.ID)
+		fileModels = append(fileModels, FileDataModel{
+			ID:            types.StringValue(file.ID),
+			Filename:      types.StringValue(file.Filename),
+			UserID:        types.StringValue(file.UserID),
+			ExtractedHash: extractedHash,
+			ContentType:   types.StringValue(file.ContentType()),
+			Size:          types.Int64Value(file.Size()),
+			CreatedAt:     types.Int64Value(file.CreatedAt),
+			UpdatedAt:     types.Int64Value(file.UpdatedAt),
+		})
+	}
+
+	idsValue, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	filesValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: fileDataAttrTypes}, fileModels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idsValue
+	data.Files = filesValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -77,6 +77,44 @@ func (d *FunctionDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				Description: "Whether the function is global.",
 				Computed:    true,
 			},
+			"access_control": schema.SingleNestedAttribute{
+				Description: "Access control settings.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"read": schema.SingleNestedAttribute{
+						Description: "Read access settings.",
+						Computed:    true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								Description: "List of group IDs with read access.",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								Description: "List of user IDs with read access.",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+					"write": schema.SingleNestedAttribute{
+						Description: "Write access settings.",
+						Computed:    true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								Description: "List of group IDs with write access.",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								Description: "List of user IDs with write access.",
+								Computed:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+				},
+			},
 			"created_at": schema.Int64Attribute{
 				Description: "Timestamp when the function was created.",
 				Computed:    true,
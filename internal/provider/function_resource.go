@@ -14,11 +14,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-openwebui/internal/provider/client/functions"
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 var (
 	_ resource.Resource                = &FunctionResource{}
 	_ resource.ResourceWithImportState = &FunctionResource{}
+	_ resource.ResourceWithModifyPlan  = &FunctionResource{}
 )
 
 func NewFunctionResource() resource.Resource {
@@ -29,6 +31,14 @@ type FunctionResource struct {
 	client *functions.Client
 }
 
+// FunctionResourceModel extends the function model with the sources of its code
+type FunctionResourceModel struct {
+	functions.Function
+	SourceFile  types.String `tfsdk:"source_file"`
+	SourceURL   types.String `tfsdk:"source_url"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
 func (r *FunctionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_function"
 }
@@ -72,33 +82,70 @@ func (r *FunctionResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "The name of the function.",
-				Required:    true,
+				Description: "The name of the function. Defaults to the title in the frontmatter of the code.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"type": schema.StringAttribute{
 				Description: "The type of the function.",
 				Optional:    true,
 				Computed:    true,
 			},
-			"content": schema.StringAttribute{
-				Description: "The content/code of the function.",
-				Required:    true,
-			},
 			"meta": schema.SingleNestedAttribute{
 				Description: "Function metadata.",
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
 					"description": schema.StringAttribute{
-						Description: "Description of the function.",
+						Description: "Description of the function. Defaults to the description in the frontmatter of the code.",
 						Optional:    true,
+						Computed:    true,
 					},
 					"manifest": schema.MapAttribute{
-						Description: "Function manifest metadata.",
+						Description: "Function manifest metadata. Defaults to the frontmatter of the code, which OpenWebUI stores as the manifest.",
 						Optional:    true,
+						Computed:    true,
 						ElementType: types.StringType,
 					},
 				},
 			},
+			"access_control": schema.SingleNestedAttribute{
+				Description: "Access control settings.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"read": schema.SingleNestedAttribute{
+						Description: "Read access settings.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								Description: "List of group IDs with read access.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								Description: "List of user IDs with read access.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+					"write": schema.SingleNestedAttribute{
+						Description: "Write access settings.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								Description: "List of group IDs with write access.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								Description: "List of user IDs with write access.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+				},
+			},
 			"is_active": schema.BoolAttribute{
 				Description: "Whether the function is active.",
 				Optional:    true,
@@ -121,10 +168,24 @@ func (r *FunctionResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			},
 		},
 	}
+
+	for name, attribute := range sourceContentAttributes("function") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *FunctionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planSourceContent(ctx, req, resp, func(url string) (string, error) {
+		source, err := r.client.LoadURL(url)
+		if err != nil {
+			return "", err
+		}
+		return source.Content, nil
+	})
 }
 
 func (r *FunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan functions.Function
+	var plan FunctionResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -161,14 +222,28 @@ func (r *FunctionResource) Create(ctx context.Context, req resource.CreateReques
 		}
 	}
 
+	// Handle AccessControl
+	apiFunction.AccessControl = models.AccessControlToAPI(plan.AccessControl)
+
 	function, err := r.client.Create(apiFunction)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating function", err.Error())
 		return
 	}
 
+	// A failed toggle still saves the function, so the next apply retries it
+	function, err = r.reconcileToggles(function, &plan.Function)
+	if err != nil {
+		resp.Diagnostics.AddError("Error toggling function", err.Error())
+	}
+
 	// Convert API response back to Terraform model
-	state := functions.APIToFunction(function)
+	state := &FunctionResourceModel{
+		Function:    *functions.APIToFunction(function),
+		SourceFile:  plan.SourceFile,
+		SourceURL:   plan.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(function.Content))),
+	}
 
 	// Ensure the ID is set in the state
 	if state.ID.IsNull() {
@@ -181,7 +256,7 @@ func (r *FunctionResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *FunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state functions.Function
+	var state FunctionResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -195,7 +270,12 @@ func (r *FunctionResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Convert API response to Terraform model
-	newState := functions.APIToFunction(function)
+	newState := &FunctionResourceModel{
+		Function:    *functions.APIToFunction(function),
+		SourceFile:  state.SourceFile,
+		SourceURL:   state.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(function.Content))),
+	}
 
 	// Ensure the ID is preserved
 	if newState.ID.IsNull() {
@@ -207,14 +287,14 @@ func (r *FunctionResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *FunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan functions.Function
+	var plan FunctionResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state functions.Function
+	var state FunctionResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -251,14 +331,28 @@ func (r *FunctionResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 	}
 
+	// Handle AccessControl
+	apiFunction.AccessControl = models.AccessControlToAPI(plan.AccessControl)
+
 	function, err := r.client.Update(state.ID.ValueString(), apiFunction)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating function", err.Error())
 		return
 	}
 
+	// A failed toggle still saves the function, so the next apply retries it
+	function, err = r.reconcileToggles(function, &plan.Function)
+	if err != nil {
+		resp.Diagnostics.AddError("Error toggling function", err.Error())
+	}
+
 	// Convert API response back to Terraform model
-	newState := functions.APIToFunction(function)
+	newState := &FunctionResourceModel{
+		Function:    *functions.APIToFunction(function),
+		SourceFile:  plan.SourceFile,
+		SourceURL:   plan.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(function.Content))),
+	}
 
 	// Ensure the ID is preserved
 	if newState.ID.IsNull() {
@@ -270,7 +364,7 @@ func (r *FunctionResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *FunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state functions.Function
+	var state FunctionResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -284,6 +378,26 @@ func (r *FunctionResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// reconcileToggles flips is_active and is_global through the toggle endpoints
+// until they match the plan, as the API ignores them in the request body.
+func (r *FunctionResource) reconcileToggles(function *functions.APIFunction, plan *functions.Function) (*functions.APIFunction, error) {
+	if function.IsActive != plan.IsActive.ValueBool() {
+		toggled, err := r.client.Toggle(function.ID)
+		if err != nil {
+			return function, err
+		}
+		function = toggled
+	}
+	if function.IsGlobal != plan.IsGlobal.ValueBool() {
+		toggled, err := r.client.ToggleGlobal(function.ID)
+		if err != nil {
+			return function, err
+		}
+		function = toggled
+	}
+	return function, nil
+}
+
 func (r *FunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-openwebui/internal/provider/client/files"
 	"terraform-provider-openwebui/internal/provider/client/knowledge"
 )
 
@@ -23,18 +24,22 @@ func NewKnowledgeDataSource() datasource.DataSource {
 
 // KnowledgeDataSource defines the data source implementation.
 type KnowledgeDataSource struct {
-	client *knowledge.Client
+	client      *knowledge.Client
+	filesClient *files.Client
 }
 
 // KnowledgeDataSourceModel describes the data source data model.
 type KnowledgeDataSourceModel struct {
 	ID            types.String `tfsdk:"id"`
+	UserID        types.String `tfsdk:"user_id"`
 	Name          types.String `tfsdk:"name"`
 	Description   types.String `tfsdk:"description"`
 	Data          types.Map    `tfsdk:"data"`
 	AccessControl types.String `tfsdk:"access_control"`
 	AccessGroups  types.List   `tfsdk:"access_groups"`
 	AccessUsers   types.List   `tfsdk:"access_users"`
+	FileIDs       types.List   `tfsdk:"file_ids"`
+	Files         types.List   `tfsdk:"files"`
 	LastUpdated   types.String `tfsdk:"last_updated"`
 }
 
@@ -51,6 +56,10 @@ func (d *KnowledgeDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Computed:            true,
 				MarkdownDescription: "Knowledge identifier",
 			},
+			"user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the user owning the knowledge base",
+			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the knowledge base to look up",
 				Required:            true,
@@ -78,6 +87,40 @@ func (d *KnowledgeDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Computed:            true,
 				MarkdownDescription: "List of user IDs with access",
 			},
+			"file_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the files in the knowledge base",
+			},
+			"files": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Files in the knowledge base",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "File identifier",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Original filename",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size in bytes",
+						},
+						"extracted_hash": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "SHA-256 hash OpenWebUI computed over the text extracted from the file. " +
+								"It is not a hash of the raw file and never matches `content_hash` of `openwebui_knowledge_file` or `openwebui_knowledge_directory`",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Processing status ('pending', 'completed' or 'failed'), null if it could not be read",
+						},
+					},
+				},
+			},
 			"last_updated": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Timestamp of the last update",
@@ -109,7 +152,17 @@ func (d *KnowledgeDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
+	filesClient, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
 	d.client = client
+	d.filesClient = filesClient
 }
 
 func (d *KnowledgeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -134,6 +187,7 @@ func (d *KnowledgeDataSource) Read(ctx context.Context, req datasource.ReadReque
 		if kb.Name == data.Name.ValueString() {
 			// Convert API response to model
 			data.ID = types.StringValue(kb.ID)
+			data.UserID = types.StringValue(kb.UserID)
 			data.Description = types.StringValue(kb.Description)
 			data.LastUpdated = types.StringValue(fmt.Sprint(kb.UpdatedAt))
 
@@ -160,40 +214,16 @@ func (d *KnowledgeDataSource) Read(ctx context.Context, req datasource.ReadReque
 				data.AccessControl = types.StringValue("private")
 
 				// Extract groups and users from access control
-				if ac, ok := kb.AccessControl.(map[string]interface{}); ok {
-					if read, ok := ac["read"].(map[string]interface{}); ok {
-						// Handle groups
-						if groups, ok := read["group_ids"].([]interface{}); ok {
-							groupList := make([]string, 0, len(groups))
-							for _, g := range groups {
-								if str, ok := g.(string); ok {
-									groupList = append(groupList, str)
-								}
-							}
-							groupsValue, err := types.ListValueFrom(ctx, types.StringType, groupList)
-							if err != nil {
-								resp.Diagnostics.AddError("Conversion Error", fmt.Sprintf("Unable to convert groups list, got error: %s", err))
-								return
-							}
-							data.AccessGroups = groupsValue
-						}
-
-						// Handle users
-						if users, ok := read["user_ids"].([]interface{}); ok {
-							userList := make([]string, 0, len(users))
-							for _, u := range users {
-								if str, ok := u.(string); ok {
-									userList = append(userList, str)
-								}
-							}
-							usersValue, err := types.ListValueFrom(ctx, types.StringType, userList)
-							if err != nil {
-								resp.Diagnostics.AddError("Conversion Error", fmt.Sprintf("Unable to convert users list, got error: %s", err))
-								return
-							}
-							data.AccessUsers = usersValue
-						}
+				if read := kb.AccessControl.Read; read != nil {
+					groupsValue, diags := types.ListValueFrom(ctx, types.StringType, read.GroupIDs)
+					resp.Diagnostics.Append(diags...)
+					usersValue, diags := types.ListValueFrom(ctx, types.StringType, read.UserIDs)
+					resp.Diagnostics.Append(diags...)
+					if resp.Diagnostics.HasError() {
+						return
 					}
+					data.AccessGroups = groupsValue
+					data.AccessUsers = usersValue
 				}
 			}
 
@@ -210,6 +240,22 @@ func (d *KnowledgeDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	// The list response does not include files in all versions, so fetch the
+	// knowledge base itself
+	kb, err := d.client.Get(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read knowledge base, got error: %s", err))
+		return
+	}
+
+	fileIDs, filesValue, diags := knowledgeFilesValue(ctx, d.filesClient, kb.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.FileIDs = fileIDs
+	data.Files = filesValue
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,555 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &KnowledgeDirectoryResource{}
+var _ resource.ResourceWithModifyPlan = &KnowledgeDirectoryResource{}
+
+func NewKnowledgeDirectoryResource() resource.Resource {
+	return &KnowledgeDirectoryResource{}
+}
+
+// KnowledgeDirectoryResource defines the resource implementation.
+type KnowledgeDirectoryResource struct {
+	client      *knowledge.Client
+	filesClient *files.Client
+}
+
+// KnowledgeDirectoryResourceModel describes the resource data model.
+type KnowledgeDirectoryResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	KnowledgeID types.String `tfsdk:"knowledge_id"`
+	Directory   types.String `tfsdk:"directory"`
+	Patterns    types.List   `tfsdk:"patterns"`
+	Files       types.Map    `tfsdk:"files"`
+	StaleFiles  types.Set    `tfsdk:"stale_file_ids"`
+}
+
+// KnowledgeDirectoryFileModel describes a synced file, keyed by its path
+// relative to the directory.
+type KnowledgeDirectoryFileModel struct {
+	ID          types.String `tfsdk:"id"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+var knowledgeDirectoryFileAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"content_hash": types.StringType,
+}
+
+func (r *KnowledgeDirectoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_knowledge_directory"
+}
+
+func (r *KnowledgeDirectoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs the files of a local directory into an OpenWebUI knowledge base. " +
+			"New files are uploaded, changed files are uploaded again and replace the previous version, and deleted files are removed. " +
+			"Files already in the knowledge base that were not added by this resource are left untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the sync, the knowledge base ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"knowledge_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the knowledge base to sync the files into",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"directory": schema.StringAttribute{
+				MarkdownDescription: "Path of the local directory to sync",
+				Required:            true,
+			},
+			"patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Glob patterns, relative to `directory`, selecting the files to sync. " +
+					"`*` matches within a path segment and `**` matches any number of segments. Defaults to all files (`[\"**\"]`)",
+				Optional: true,
+				Computed: true,
+				Default:  listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("**")})),
+			},
+			"files": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Synced files, keyed by their path relative to `directory`",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the uploaded file",
+						},
+						"content_hash": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA-256 hash of the uploaded content",
+						},
+					},
+				},
+			},
+			"stale_file_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of replaced files that could not be removed from the knowledge base. Their removal is retried on the next apply",
+			},
+		},
+	}
+}
+
+func (r *KnowledgeDirectoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["knowledge"].(*knowledge.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *knowledge.Client, got: %T. Please report this issue to the provider developers.", clients["knowledge"]),
+		)
+		return
+	}
+
+	filesClient, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
+	r.client = client
+	r.filesClient = filesClient
+}
+
+func (r *KnowledgeDirectoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to scan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan KnowledgeDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Directory.IsUnknown() || plan.Patterns.IsUnknown() {
+		return
+	}
+
+	patterns := make([]string, 0, len(plan.Patterns.Elements()))
+	resp.Diagnostics.Append(plan.Patterns.ElementsAs(ctx, &patterns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	local, err := scanKnowledgeDirectory(plan.Directory.ValueString(), patterns)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("directory"), "Unable to Read Directory", err.Error())
+		return
+	}
+
+	prior := map[string]KnowledgeDirectoryFileModel{}
+	if !req.State.Raw.IsNull() {
+		var state KnowledgeDirectoryResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Plan every file with its new hash so the diff shows exactly which files
+	// are added, replaced or removed. Replaced files get a new ID.
+	planned := make(map[string]KnowledgeDirectoryFileModel, len(local))
+	for name, content := range local {
+		hash := fileContentHash(content)
+		file := KnowledgeDirectoryFileModel{ID: types.StringUnknown(), ContentHash: types.StringValue(hash)}
+		if existing, ok := prior[name]; ok && existing.ContentHash.ValueString() == hash {
+			file.ID = existing.ID
+		}
+		planned[name] = file
+	}
+
+	filesValue, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: knowledgeDirectoryFileAttrTypes}, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("files"), filesValue)...)
+
+	// Plan stale files as removed, so that a pending removal triggers an update
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("stale_file_ids"), types.SetNull(types.StringType))...)
+}
+
+func (r *KnowledgeDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KnowledgeDirectoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &data, map[string]KnowledgeDirectoryFileModel{}, nil)...)
+	data.ID = data.KnowledgeID
+
+	// Save data into Terraform state, including the files synced before a failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KnowledgeDirectoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get knowledge base from API
+	result, err := r.client.Get(data.KnowledgeID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read knowledge base, got error: %s", err))
+		return
+	}
+
+	var synced map[string]KnowledgeDirectoryFileModel
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &synced, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Drop files removed outside of Terraform so the next apply uploads them again
+	present := make(map[string]bool, len(result.Files))
+	for _, file := range result.Files {
+		present[file.ID] = true
+	}
+	for name, file := range synced {
+		if !present[file.ID.ValueString()] {
+			delete(synced, name)
+		}
+	}
+	var stale []string
+	resp.Diagnostics.Append(data.StaleFiles.ElementsAs(ctx, &stale, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	remaining := make([]string, 0, len(stale))
+	for _, fileID := range stale {
+		if present[fileID] {
+			remaining = append(remaining, fileID)
+		}
+	}
+
+	filesValue, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: knowledgeDirectoryFileAttrTypes}, synced)
+	resp.Diagnostics.Append(diags...)
+	staleValue, diags := staleFilesValue(ctx, remaining)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Files = filesValue
+	data.StaleFiles = staleValue
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state KnowledgeDirectoryResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]KnowledgeDirectoryFileModel{}
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &prior, false)...)
+	var stale []string
+	resp.Diagnostics.Append(state.StaleFiles.ElementsAs(ctx, &stale, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &data, prior, stale)...)
+
+	// Save updated data into Terraform state, including the files synced before a failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KnowledgeDirectoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var synced map[string]KnowledgeDirectoryFileModel
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &synced, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stale []string
+	resp.Diagnostics.Append(data.StaleFiles.ElementsAs(ctx, &stale, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove every synced file from the knowledge base and delete it
+	for name, file := range synced {
+		_, err := r.client.RemoveFile(data.KnowledgeID.ValueString(), file.ID.ValueString(), true)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove file %s from knowledge base, got error: %s", name, err))
+			return
+		}
+	}
+	for _, fileID := range stale {
+		if _, err := r.client.RemoveFile(data.KnowledgeID.ValueString(), fileID, true); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove replaced file %s from knowledge base, got error: %s", fileID, err))
+			return
+		}
+	}
+}
+
+// sync uploads new and changed files, adds them to the knowledge base in one
+// batch and removes deleted and replaced files. The files actually in the
+// knowledge base afterwards are written to data, even when sync fails part
+// way, so that state never loses track of added files. Replaced files that
+// cannot be removed are kept as stale and retried on the next sync.
+func (r *KnowledgeDirectoryResource) sync(ctx context.Context, data *KnowledgeDirectoryResourceModel, prior map[string]KnowledgeDirectoryFileModel, stale []string) (diags diag.Diagnostics) {
+	synced := make(map[string]KnowledgeDirectoryFileModel, len(prior))
+	for name, file := range prior {
+		synced[name] = file
+	}
+	replaced := append([]string(nil), stale...)
+	remaining := stale
+	defer func() {
+		filesValue, mapDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: knowledgeDirectoryFileAttrTypes}, synced)
+		diags.Append(mapDiags...)
+		data.Files = filesValue
+		staleValue, setDiags := staleFilesValue(ctx, remaining)
+		diags.Append(setDiags...)
+		data.StaleFiles = staleValue
+	}()
+
+	patterns := make([]string, 0, len(data.Patterns.Elements()))
+	diags.Append(data.Patterns.ElementsAs(ctx, &patterns, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	local, err := scanKnowledgeDirectory(data.Directory.ValueString(), patterns)
+	if err != nil {
+		diags.AddError("Unable to Read Directory", err.Error())
+		return diags
+	}
+
+	knowledgeID := data.KnowledgeID.ValueString()
+	pending := make(map[string]KnowledgeDirectoryFileModel)
+	var uploaded []string
+
+	names := make([]string, 0, len(local))
+	for name := range local {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		hash := fileContentHash(local[name])
+		if existing, ok := prior[name]; ok && existing.ContentHash.ValueString() == hash {
+			continue
+		}
+
+		fileID, err := uploadProcessedFile(r.filesClient, pathpkg.Base(name), local[name])
+		if err != nil {
+			r.deleteFiles(uploaded)
+			diags.AddError("Client Error", fmt.Sprintf("Unable to upload file %s, got error: %s", name, err))
+			return diags
+		}
+		uploaded = append(uploaded, fileID)
+		pending[name] = KnowledgeDirectoryFileModel{ID: types.StringValue(fileID), ContentHash: types.StringValue(hash)}
+	}
+
+	if len(uploaded) > 0 {
+		result, err := r.client.AddFilesBatch(knowledgeID, uploaded)
+		if err != nil {
+			r.deleteFiles(uploaded)
+			diags.AddError("Client Error", fmt.Sprintf("Unable to add files to knowledge base, got error: %s", err))
+			return diags
+		}
+
+		// OpenWebUI adds the files it could process and only warns about the others
+		added := make(map[string]bool, len(result.Files))
+		for _, file := range result.Files {
+			added[file.ID] = true
+		}
+		for _, name := range names {
+			file, ok := pending[name]
+			if !ok {
+				continue
+			}
+			if !added[file.ID.ValueString()] {
+				r.deleteFiles([]string{file.ID.ValueString()})
+				diags.AddError("Client Error", fmt.Sprintf("Unable to add file %s to knowledge base", name))
+				continue
+			}
+			if existing, ok := prior[name]; ok {
+				replaced = append(replaced, existing.ID.ValueString())
+			}
+			synced[name] = file
+		}
+	}
+
+	for name, file := range prior {
+		if _, ok := local[name]; ok {
+			continue
+		}
+		if _, err := r.client.RemoveFile(knowledgeID, file.ID.ValueString(), true); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove file %s from knowledge base, got error: %s", name, err))
+			continue
+		}
+		delete(synced, name)
+	}
+	remaining = nil
+	for _, fileID := range replaced {
+		if _, err := r.client.RemoveFile(knowledgeID, fileID, true); err != nil {
+			remaining = append(remaining, fileID)
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove replaced file %s from knowledge base, got error: %s", fileID, err))
+		}
+	}
+
+	return diags
+}
+
+// staleFilesValue returns the IDs of stale files as a set value, null when
+// there are none.
+func staleFilesValue(ctx context.Context, fileIDs []string) (types.Set, diag.Diagnostics) {
+	if len(fileIDs) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, fileIDs)
+}
+
+// deleteFiles deletes uploaded files that could not be added to the knowledge base.
+func (r *KnowledgeDirectoryResource) deleteFiles(fileIDs []string) {
+	for _, fileID := range fileIDs {
+		_ = r.filesClient.Delete(fileID)
+	}
+}
+
+// scanKnowledgeDirectory returns the content of every regular file below dir
+// matching one of the patterns, keyed by its slash separated relative path.
+func scanKnowledgeDirectory(dir string, patterns []string) (map[string][]byte, error) {
+	for _, pattern := range patterns {
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := pathpkg.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+			}
+		}
+	}
+
+	result := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, pattern := range patterns {
+			if matchGlob(pattern, rel) {
+				content, err := os.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				result[rel] = content
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// matchGlob reports whether a slash separated path matches a glob pattern,
+// where "**" matches any number of path segments.
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchGlobSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := pathpkg.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchGlobSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,215 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"**", "policy.md", true},
+		{"**", "hr/leave/policy.md", true},
+		{"*.md", "policy.md", true},
+		{"*.md", "hr/policy.md", false},
+		{"**/*.md", "policy.md", true},
+		{"**/*.md", "hr/leave/policy.md", true},
+		{"hr/**/*.pdf", "hr/handbook.pdf", true},
+		{"hr/**/*.pdf", "it/handbook.pdf", false},
+		{"hr/*", "hr/leave/policy.md", false},
+	}
+
+	for _, c := range cases {
+		if got := matchGlob(c.pattern, c.name); got != c.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", c.pattern, c.name, got, c.want)
+		}
+	}
+}
+
+func TestScanKnowledgeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"README.md":        "readme",
+		"hr/leave.md":      "leave",
+		"hr/handbook.pdf":  "handbook",
+		"it/notes/todo.md": "todo",
+	} {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := scanKnowledgeDirectory(dir, []string{"hr/**/*.md", "it/**"})
+	if err != nil {
+		t.Fatalf("scanKnowledgeDirectory returned error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 files, got %d: %v", len(result), result)
+	}
+	if string(result["hr/leave.md"]) != "leave" {
+		t.Errorf("Expected 'hr/leave.md' to contain 'leave', got '%s'", result["hr/leave.md"])
+	}
+	if _, ok := result["it/notes/todo.md"]; !ok {
+		t.Error("Expected 'it/notes/todo.md' to be included")
+	}
+
+	if _, err := scanKnowledgeDirectory(dir, []string{"[invalid"}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestKnowledgeDirectorySyncPartialBatch(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.md": "a", "b.md": "b"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/files/":
+			_, header, err := r.FormFile("file")
+			if err != nil {
+				t.Error(err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id": "file-" + header.Filename})
+		case strings.HasSuffix(r.URL.Path, "/process/status"):
+			json.NewEncoder(w).Encode(map[string]string{"status": "completed"})
+		case r.URL.Path == "/api/v1/knowledge/kb/files/batch/add":
+			// Only the first file could be processed
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":    "kb",
+				"files": []map[string]string{{"id": "file-a.md"}},
+			})
+		case r.Method == "DELETE":
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/v1/files/"))
+			json.NewEncoder(w).Encode(true)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &KnowledgeDirectoryResource{
+		client:      knowledge.NewClient(server.URL, "token"),
+		filesClient: files.NewClient(server.URL, "token"),
+	}
+	patterns, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"*.md"})
+	data := KnowledgeDirectoryResourceModel{
+		KnowledgeID: types.StringValue("kb"),
+		Directory:   types.StringValue(dir),
+		Patterns:    patterns,
+	}
+
+	diags := r.sync(context.Background(), &data, map[string]KnowledgeDirectoryFileModel{}, nil)
+	if !diags.HasError() {
+		t.Error("Expected an error for the file missing from the batch")
+	}
+
+	var synced map[string]KnowledgeDirectoryFileModel
+	data.Files.ElementsAs(context.Background(), &synced, false)
+	if len(synced) != 1 || synced["a.md"].ID.ValueString() != "file-a.md" {
+		t.Errorf("Expected only a.md to be synced, got %v", synced)
+	}
+	if len(deleted) != 1 || deleted[0] != "file-b.md" {
+		t.Errorf("Expected the unadded file to be deleted, got %v", deleted)
+	}
+}
+
+func TestKnowledgeDirectorySyncStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	removeFails := true
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/files/":
+			json.NewEncoder(w).Encode(map[string]string{"id": "new"})
+		case strings.HasSuffix(r.URL.Path, "/process/status"):
+			json.NewEncoder(w).Encode(map[string]string{"status": "completed"})
+		case r.URL.Path == "/api/v1/knowledge/kb/files/batch/add":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":    "kb",
+				"files": []map[string]string{{"id": "new"}},
+			})
+		case r.URL.Path == "/api/v1/knowledge/kb/file/remove":
+			if removeFails {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var form knowledge.KnowledgeFileIDForm
+			json.NewDecoder(r.Body).Decode(&form)
+			removed = append(removed, form.FileID)
+			json.NewEncoder(w).Encode(map[string]string{"id": "kb"})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &KnowledgeDirectoryResource{
+		client:      knowledge.NewClient(server.URL, "token"),
+		filesClient: files.NewClient(server.URL, "token"),
+	}
+	patterns, _ := types.ListValueFrom(ctx, types.StringType, []string{"*.md"})
+	data := KnowledgeDirectoryResourceModel{
+		KnowledgeID: types.StringValue("kb"),
+		Directory:   types.StringValue(dir),
+		Patterns:    patterns,
+	}
+	prior := map[string]KnowledgeDirectoryFileModel{
+		"a.md": {ID: types.StringValue("old"), ContentHash: types.StringValue(fileContentHash([]byte("old")))},
+	}
+
+	if diags := r.sync(ctx, &data, prior, nil); !diags.HasError() {
+		t.Error("Expected an error for the failed removal")
+	}
+	var stale []string
+	data.StaleFiles.ElementsAs(ctx, &stale, false)
+	if len(stale) != 1 || stale[0] != "old" {
+		t.Fatalf("Expected the replaced file to be stale, got %v", stale)
+	}
+
+	// The next sync retries the removal even though no file changed
+	var synced map[string]KnowledgeDirectoryFileModel
+	data.Files.ElementsAs(ctx, &synced, false)
+	removeFails = false
+	if diags := r.sync(ctx, &data, synced, stale); diags.HasError() {
+		t.Fatalf("sync returned error: %v", diags)
+	}
+	if len(removed) != 1 || removed[0] != "old" || !data.StaleFiles.IsNull() {
+		t.Errorf("Expected the stale file to be removed, got %v with stale %s", removed, data.StaleFiles)
+	}
+}
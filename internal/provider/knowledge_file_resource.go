@@ -0,0 +1,337 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+// knowledgeFileProcessingTimeout bounds how long to wait for OpenWebUI to
+// extract and embed an uploaded file
+const knowledgeFileProcessingTimeout = 10 * time.Minute
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &KnowledgeFileResource{}
+var _ resource.ResourceWithModifyPlan = &KnowledgeFileResource{}
+
+func NewKnowledgeFileResource() resource.Resource {
+	return &KnowledgeFileResource{}
+}
+
+// KnowledgeFileResource defines the resource implementation.
+type KnowledgeFileResource struct {
+	client      *knowledge.Client
+	filesClient *files.Client
+}
+
+// KnowledgeFileResourceModel describes the resource data model.
+type KnowledgeFileResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	KnowledgeID types.String `tfsdk:"knowledge_id"`
+	Source      types.String `tfsdk:"source"`
+	Filename    types.String `tfsdk:"filename"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+func (r *KnowledgeFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_knowledge_file"
+}
+
+func (r *KnowledgeFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a local file into an OpenWebUI knowledge base. " +
+			"Content changes are detected by hash: text files are updated in place, other files (e.g. PDF) are uploaded again and replace the previous file.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the uploaded file",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"knowledge_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the knowledge base to add the file to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path of the local file to upload",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "Name of the file in OpenWebUI. Defaults to the base name of `source`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the uploaded content",
+			},
+		},
+	}
+}
+
+func (r *KnowledgeFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["knowledge"].(*knowledge.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *knowledge.Client, got: %T. Please report this issue to the provider developers.", clients["knowledge"]),
+		)
+		return
+	}
+
+	filesClient, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
+	r.client = client
+	r.filesClient = filesClient
+}
+
+func (r *KnowledgeFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to hash on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan KnowledgeFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() {
+		return
+	}
+
+	content, err := os.ReadFile(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read File", err.Error())
+		return
+	}
+	hash := fileContentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state KnowledgeFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Files that cannot be updated in place are uploaded again under a new ID
+	if state.ContentHash.ValueString() != hash && !utf8.Valid(content) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *KnowledgeFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KnowledgeFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read File", err.Error())
+		return
+	}
+
+	if data.Filename.IsNull() || data.Filename.IsUnknown() {
+		data.Filename = types.StringValue(filepath.Base(data.Source.ValueString()))
+	}
+
+	fileID, err := addKnowledgeFile(r.client, r.filesClient, data.KnowledgeID.ValueString(), data.Filename.ValueString(), content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add file to knowledge base, got error: %s", err))
+		return
+	}
+
+	// Map response to model
+	data.ID = types.StringValue(fileID)
+	data.ContentHash = types.StringValue(fileContentHash(content))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KnowledgeFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get knowledge base from API
+	result, err := r.client.Get(data.KnowledgeID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read knowledge base, got error: %s", err))
+		return
+	}
+
+	for _, file := range result.Files {
+		if file.ID == data.ID.ValueString() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	// The file was removed from the knowledge base outside of Terraform
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *KnowledgeFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state KnowledgeFileResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the content can change in place; everything else requires replacement
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read File", err.Error())
+		return
+	}
+
+	knowledgeID := data.KnowledgeID.ValueString()
+	if utf8.Valid(content) {
+		if err := r.filesClient.UpdateContent(state.ID.ValueString(), string(content)); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update file content, got error: %s", err))
+			return
+		}
+		if _, err := r.client.UpdateFile(knowledgeID, state.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update file in knowledge base, got error: %s", err))
+			return
+		}
+		data.ID = state.ID
+	} else {
+		fileID, err := addKnowledgeFile(r.client, r.filesClient, knowledgeID, data.Filename.ValueString(), content)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add file to knowledge base, got error: %s", err))
+			return
+		}
+		data.ID = types.StringValue(fileID)
+		if _, err := r.client.RemoveFile(knowledgeID, state.ID.ValueString(), true); err != nil {
+			// The new file is in the knowledge base, track it rather than add it again
+			data.ContentHash = types.StringValue(fileContentHash(content))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove previous file from knowledge base, got error: %s", err))
+			return
+		}
+	}
+
+	data.ContentHash = types.StringValue(fileContentHash(content))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KnowledgeFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the file from the knowledge base and delete it
+	_, err := r.client.RemoveFile(data.KnowledgeID.ValueString(), data.ID.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove file from knowledge base, got error: %s", err))
+		return
+	}
+}
+
+// addKnowledgeFile uploads a file, waits for it to be processed and adds it to
+// the knowledge base. The uploaded file is deleted again if it cannot be added.
+func addKnowledgeFile(client *knowledge.Client, filesClient *files.Client, knowledgeID, filename string, content []byte) (string, error) {
+	fileID, err := uploadProcessedFile(filesClient, filename, content)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := client.AddFile(knowledgeID, fileID); err != nil {
+		_ = filesClient.Delete(fileID)
+		return "", err
+	}
+
+	return fileID, nil
+}
+
+// uploadProcessedFile uploads a file and waits until OpenWebUI has processed
+// it, deleting it again if processing fails.
+func uploadProcessedFile(filesClient *files.Client, filename string, content []byte) (string, error) {
+	file, err := filesClient.Upload(filename, content)
+	if err != nil {
+		return "", err
+	}
+
+	if err := filesClient.WaitForProcessing(file.ID, knowledgeFileProcessingTimeout); err != nil {
+		_ = filesClient.Delete(file.ID)
+		return "", err
+	}
+
+	return file.ID, nil
+}
+
+// fileContentHash returns the hex encoded SHA-256 hash of file content.
+func fileContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
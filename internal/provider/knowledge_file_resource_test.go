@@ -0,0 +1,86 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+func TestKnowledgeFileUpdateRemoveFailure(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(source, []byte{0xff, 0xfe, 0x00}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/files/":
+			json.NewEncoder(w).Encode(map[string]string{"id": "new"})
+		case strings.HasSuffix(r.URL.Path, "/process/status"):
+			json.NewEncoder(w).Encode(map[string]string{"status": "completed"})
+		case r.URL.Path == "/api/v1/knowledge/kb/file/add":
+			json.NewEncoder(w).Encode(map[string]string{"id": "kb"})
+		case r.URL.Path == "/api/v1/knowledge/kb/file/remove":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &KnowledgeFileResource{
+		client:      knowledge.NewClient(server.URL, "token"),
+		filesClient: files.NewClient(server.URL, "token"),
+	}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	schema := schemaResp.Schema
+	empty := tftypes.NewValue(schema.Type().TerraformType(ctx), nil)
+
+	prior := KnowledgeFileResourceModel{
+		ID:          types.StringValue("old"),
+		KnowledgeID: types.StringValue("kb"),
+		Source:      types.StringValue(source),
+		Filename:    types.StringValue("report.pdf"),
+		ContentHash: types.StringValue("previous"),
+	}
+	planned := prior
+	planned.ContentHash = types.StringUnknown()
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schema, Raw: empty},
+		State: tfsdk.State{Schema: schema, Raw: empty},
+	}
+	req.Plan.Set(ctx, &planned)
+	req.State.Set(ctx, &prior)
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema, Raw: empty}}
+
+	r.Update(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for the failed removal")
+	}
+
+	var saved KnowledgeFileResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &saved)...)
+	if saved.ID.ValueString() != "new" || saved.ContentHash.ValueString() != fileContentHash([]byte{0xff, 0xfe, 0x00}) {
+		t.Errorf("Expected the new file to be saved, got %+v", saved)
+	}
+}
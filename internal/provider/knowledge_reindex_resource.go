@@ -0,0 +1,138 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &KnowledgeReindexResource{}
+
+func NewKnowledgeReindexResource() resource.Resource {
+	return &KnowledgeReindexResource{}
+}
+
+// KnowledgeReindexResource defines the resource implementation.
+type KnowledgeReindexResource struct {
+	client *knowledge.Client
+}
+
+// KnowledgeReindexResourceModel describes the resource data model.
+type KnowledgeReindexResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Triggers types.Map    `tfsdk:"triggers"`
+}
+
+func (r *KnowledgeReindexResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_knowledge_reindex"
+}
+
+func (r *KnowledgeReindexResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reindexes every OpenWebUI knowledge base when created or when `triggers` change, " +
+			"so files are re-embedded in the same apply that changed chunking or embedding settings. Destroying it does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the last reindex",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values; any change reindexes all knowledge bases again",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *KnowledgeReindexResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["knowledge"].(*knowledge.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *knowledge.Client, got: %T. Please report this issue to the provider developers.", clients["knowledge"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *KnowledgeReindexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KnowledgeReindexResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Reindex(); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reindex knowledge bases, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeReindexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A reindex is a one-off operation with nothing to read back; keep the prior state
+	var data KnowledgeReindexResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeReindexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Changing triggers requires replacement, so there is nothing to update
+	var data KnowledgeReindexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeReindexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to undo; removing the resource from state is sufficient
+}
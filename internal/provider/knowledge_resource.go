@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,12 +16,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-openwebui/internal/provider/client/files"
 	"terraform-provider-openwebui/internal/provider/client/knowledge"
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &KnowledgeResource{}
 var _ resource.ResourceWithImportState = &KnowledgeResource{}
+var _ resource.ResourceWithUpgradeState = &KnowledgeResource{}
 
 func NewKnowledgeResource() resource.Resource {
 	return &KnowledgeResource{}
@@ -27,17 +32,39 @@ func NewKnowledgeResource() resource.Resource {
 
 // KnowledgeResource defines the resource implementation.
 type KnowledgeResource struct {
-	client *knowledge.Client
+	client      *knowledge.Client
+	filesClient *files.Client
 }
 
 // KnowledgeResourceModel describes the resource data model.
 type KnowledgeResourceModel struct {
+	ID             types.String          `tfsdk:"id"`
+	UserID         types.String          `tfsdk:"user_id"`
+	Name           types.String          `tfsdk:"name"`
+	Description    types.String          `tfsdk:"description"`
+	Data           types.Map             `tfsdk:"data"`
+	AccessControl  *models.AccessControl `tfsdk:"access_control"`
+	ReindexTrigger types.String          `tfsdk:"reindex_trigger"`
+	FileIDs        types.List            `tfsdk:"file_ids"`
+	Files          types.List            `tfsdk:"files"`
+	LastUpdated    types.String          `tfsdk:"last_updated"`
+}
+
+// KnowledgeFileModel describes a file attached to a knowledge base.
+type KnowledgeFileModel struct {
 	ID            types.String `tfsdk:"id"`
 	Name          types.String `tfsdk:"name"`
-	Description   types.String `tfsdk:"description"`
-	Data          types.Map    `tfsdk:"data"`
-	AccessControl types.String `tfsdk:"access_control"`
-	LastUpdated   types.String `tfsdk:"last_updated"`
+	Size          types.Int64  `tfsdk:"size"`
+	ExtractedHash types.String `tfsdk:"extracted_hash"`
+	Status        types.String `tfsdk:"status"`
+}
+
+var knowledgeFileAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"name":           types.StringType,
+	"size":           types.Int64Type,
+	"extracted_hash": types.StringType,
+	"status":         types.StringType,
 }
 
 func (r *KnowledgeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +74,7 @@ func (r *KnowledgeResource) Metadata(ctx context.Context, req resource.MetadataR
 func (r *KnowledgeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Knowledge resource for OpenWebUI",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -56,6 +84,13 @@ func (r *KnowledgeResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the user owning the knowledge base",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the knowledge base",
 				Required:            true,
@@ -69,10 +104,81 @@ func (r *KnowledgeResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 				MarkdownDescription: "Additional data for the knowledge base",
 			},
-			"access_control": schema.StringAttribute{
-				MarkdownDescription: "Access control type ('public' or 'private')",
+			"access_control": schema.SingleNestedAttribute{
+				MarkdownDescription: "Access control settings. The knowledge base is public when unset and private to its owner when set without grants",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"read": schema.SingleNestedAttribute{
+						MarkdownDescription: "Read access settings",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								MarkdownDescription: "List of group IDs with read access",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								MarkdownDescription: "List of user IDs with read access",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+						},
+					},
+					"write": schema.SingleNestedAttribute{
+						MarkdownDescription: "Write access settings",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"group_ids": schema.ListAttribute{
+								MarkdownDescription: "List of group IDs with write access",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+							"user_ids": schema.ListAttribute{
+								MarkdownDescription: "List of user IDs with write access",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+						},
+					},
+				},
+			},
+			"reindex_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; any change re-embeds every file of the knowledge base, e.g. after changing chunking or embedding settings",
 				Optional:            true,
+			},
+			"file_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the files in the knowledge base",
+			},
+			"files": schema.ListNestedAttribute{
 				Computed:            true,
+				MarkdownDescription: "Files in the knowledge base",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "File identifier",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Original filename",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size in bytes",
+						},
+						"extracted_hash": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "SHA-256 hash OpenWebUI computed over the text extracted from the file. " +
+								"It is not a hash of the raw file and never matches `content_hash` of `openwebui_knowledge_file` or `openwebui_knowledge_directory`",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Processing status ('pending', 'completed' or 'failed'), null if it could not be read",
+						},
+					},
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed:            true,
@@ -105,7 +211,17 @@ func (r *KnowledgeResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
+	filesClient, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
 	r.client = client
+	r.filesClient = filesClient
 }
 
 func (r *KnowledgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -134,14 +250,7 @@ func (r *KnowledgeResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Handle access control
-	if !data.AccessControl.IsNull() {
-		accessType := data.AccessControl.ValueString()
-		if accessType == "private" {
-			form.AccessControl = map[string]interface{}{
-				"type": "private",
-			}
-		}
-	}
+	form.AccessControl = models.AccessControlToAPI(data.AccessControl)
 
 	// Create new knowledge base
 	result, err := r.client.Create(form)
@@ -152,7 +261,15 @@ func (r *KnowledgeResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Map response to model
 	data.ID = types.StringValue(result.ID)
+	data.UserID = types.StringValue(result.UserID)
 	data.LastUpdated = types.StringValue(fmt.Sprint(result.UpdatedAt))
+	fileIDs, filesValue, diags := knowledgeFilesValue(ctx, r.filesClient, result.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.FileIDs = fileIDs
+	data.Files = filesValue
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -175,6 +292,7 @@ func (r *KnowledgeResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Map response to model
+	data.UserID = types.StringValue(result.UserID)
 	data.Name = types.StringValue(result.Name)
 	data.Description = types.StringValue(result.Description)
 	data.LastUpdated = types.StringValue(fmt.Sprint(result.UpdatedAt))
@@ -196,21 +314,27 @@ func (r *KnowledgeResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Handle access control
-	if result.AccessControl == nil {
-		data.AccessControl = types.StringValue("public")
-	} else {
-		data.AccessControl = types.StringValue("private")
+	data.AccessControl = models.APIToAccessControl(result.AccessControl)
+
+	// Map files
+	fileIDs, filesValue, diags := knowledgeFilesValue(ctx, r.filesClient, result.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	data.FileIDs = fileIDs
+	data.Files = filesValue
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *KnowledgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data KnowledgeResourceModel
+	var data, state KnowledgeResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -232,14 +356,7 @@ func (r *KnowledgeResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Handle access control
-	if !data.AccessControl.IsNull() {
-		accessType := data.AccessControl.ValueString()
-		if accessType == "private" {
-			form.AccessControl = map[string]interface{}{
-				"type": "private",
-			}
-		}
-	}
+	form.AccessControl = models.AccessControlToAPI(data.AccessControl)
 
 	// Update knowledge base
 	result, err := r.client.Update(data.ID.ValueString(), form)
@@ -248,8 +365,28 @@ func (r *KnowledgeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Update last updated timestamp
+	// Re-embed every file when the reindex trigger changed
+	if !data.ReindexTrigger.Equal(state.ReindexTrigger) {
+		files := result.Files
+		for _, file := range files {
+			result, err = r.client.UpdateFile(data.ID.ValueString(), file.ID)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reindex file %s, got error: %s", file.ID, err))
+				return
+			}
+		}
+	}
+
+	// Map response to model
+	data.UserID = types.StringValue(result.UserID)
 	data.LastUpdated = types.StringValue(fmt.Sprint(result.UpdatedAt))
+	fileIDs, filesValue, diags := knowledgeFilesValue(ctx, r.filesClient, result.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.FileIDs = fileIDs
+	data.Files = filesValue
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -275,3 +412,97 @@ func (r *KnowledgeResource) Delete(ctx context.Context, req resource.DeleteReque
 func (r *KnowledgeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// knowledgeResourceModelV0 describes the data model before access_control
+// became a nested attribute.
+type knowledgeResourceModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Data           types.Map    `tfsdk:"data"`
+	AccessControl  types.String `tfsdk:"access_control"`
+	ReindexTrigger types.String `tfsdk:"reindex_trigger"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+}
+
+func (r *KnowledgeResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// access_control changed from 'public'/'private' to nested grants
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Computed: true},
+					"name":            schema.StringAttribute{Required: true},
+					"description":     schema.StringAttribute{Required: true},
+					"data":            schema.MapAttribute{ElementType: types.StringType, Optional: true},
+					"access_control":  schema.StringAttribute{Optional: true, Computed: true},
+					"reindex_trigger": schema.StringAttribute{Optional: true},
+					"last_updated":    schema.StringAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior knowledgeResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := KnowledgeResourceModel{
+					ID:             prior.ID,
+					Name:           prior.Name,
+					Description:    prior.Description,
+					Data:           prior.Data,
+					ReindexTrigger: prior.ReindexTrigger,
+					LastUpdated:    prior.LastUpdated,
+					UserID:         types.StringNull(),
+					FileIDs:        types.ListNull(types.StringType),
+					Files:          types.ListNull(types.ObjectType{AttrTypes: knowledgeFileAttrTypes}),
+				}
+				// The next refresh reads the actual grants
+				if prior.AccessControl.ValueString() == "private" {
+					data.AccessControl = &models.AccessControl{}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// knowledgeFilesValue converts the files of a knowledge base into file_ids and
+// files values. The processing status comes from the knowledge base response
+// when it includes the file data; otherwise it is looked up per file, and a
+// failed lookup leaves the status null rather than failing the refresh.
+func knowledgeFilesValue(ctx context.Context, filesClient *files.Client, kbFiles []knowledge.KnowledgeFile) (types.List, types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	fileIDs := make([]string, 0, len(kbFiles))
+	fileModels := make([]KnowledgeFileModel, 0, len(kbFiles))
+	for _, file := range kbFiles {
+		status := types.StringNull()
+		if file.Status() != "" {
+			status = types.StringValue(file.Status())
+		} else if result, err := filesClient.GetProcessStatus(file.ID); err == nil {
+			status = types.StringValue(result.Status)
+		}
+
+		hash := types.StringNull()
+		if file.Hash != "" {
+			hash = types.StringValue(file.Hash)
+		}
+
+		fileIDs = append(fileIDs, file.ID)
+		fileModels = append(fileModels, KnowledgeFileModel{
+			ID:            types.StringValue(file.ID),
+			Name:          types.StringValue(file.Name()),
+			Size:          types.Int64Value(file.Size()),
+			ExtractedHash: hash,
+			Status:        status,
+		})
+	}
+
+	fileIDsValue, d := types.ListValueFrom(ctx, types.StringType, fileIDs)
+	diags.Append(d...)
+	filesValue, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: knowledgeFileAttrTypes}, fileModels)
+	diags.Append(d...)
+	return fileIDsValue, filesValue, diags
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+)
+
+func TestKnowledgeFilesValueStatus(t *testing.T) {
+	lookups := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	kbFiles := []knowledge.KnowledgeFile{
+		{ID: "f1", Hash: "abc", Data: map[string]interface{}{"status": "completed"}},
+		{ID: "f2"},
+	}
+
+	_, filesValue, diags := knowledgeFilesValue(context.Background(), files.NewClient(server.URL, "token"), kbFiles)
+	if diags.HasError() {
+		t.Fatalf("knowledgeFilesValue returned error: %v", diags)
+	}
+	if lookups != 1 {
+		t.Errorf("Expected 1 status lookup, got %d", lookups)
+	}
+
+	var fileModels []KnowledgeFileModel
+	filesValue.ElementsAs(context.Background(), &fileModels, false)
+	if fileModels[0].Status.ValueString() != "completed" || fileModels[0].ExtractedHash.ValueString() != "abc" {
+		t.Errorf("Expected completed file with extracted hash 'abc', got %+v", fileModels[0])
+	}
+	if !fileModels[1].Status.IsNull() {
+		t.Errorf("Expected a null status after a failed lookup, got %s", fileModels[1].Status)
+	}
+}
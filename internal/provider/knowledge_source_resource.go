@@ -0,0 +1,468 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/knowledge"
+	"terraform-provider-openwebui/internal/provider/client/retrieval"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &KnowledgeSourceResource{}
+var _ resource.ResourceWithModifyPlan = &KnowledgeSourceResource{}
+
+// knowledgeSourceFilenameUnsafe matches characters not kept in generated file names
+var knowledgeSourceFilenameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func NewKnowledgeSourceResource() resource.Resource {
+	return &KnowledgeSourceResource{}
+}
+
+// KnowledgeSourceResource defines the resource implementation.
+type KnowledgeSourceResource struct {
+	client          *knowledge.Client
+	filesClient     *files.Client
+	retrievalClient *retrieval.Client
+}
+
+// KnowledgeSourceResourceModel describes the resource data model.
+type KnowledgeSourceResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	KnowledgeID    types.String `tfsdk:"knowledge_id"`
+	URL            types.String `tfsdk:"url"`
+	YoutubeURL     types.String `tfsdk:"youtube_url"`
+	Text           types.String `tfsdk:"text"`
+	Name           types.String `tfsdk:"name"`
+	RefreshTrigger types.String `tfsdk:"refresh_trigger"`
+	Filename       types.String `tfsdk:"filename"`
+	ContentHash    types.String `tfsdk:"content_hash"`
+}
+
+func (r *KnowledgeSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_knowledge_source"
+}
+
+func (r *KnowledgeSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ingests a web page, YouTube transcript or raw text into an OpenWebUI knowledge base. " +
+			"The content is processed with the retrieval API into the collection of a file that is added to the knowledge base. " +
+			"Changing the source or `refresh_trigger` fetches it again and replaces the file when the content changed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the file holding the extracted content",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"knowledge_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the knowledge base to add the content to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of a web page to ingest. Exactly one of `url`, `youtube_url` or `text` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("youtube_url"), path.MatchRoot("text")),
+				},
+			},
+			"youtube_url": schema.StringAttribute{
+				MarkdownDescription: "URL of a YouTube video whose transcript to ingest",
+				Optional:            true,
+			},
+			"text": schema.StringAttribute{
+				MarkdownDescription: "Raw text to ingest. Requires `name`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the content in the knowledge base. Defaults to the URL for web pages and videos",
+				Optional:            true,
+			},
+			"refresh_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; any change fetches the source again, e.g. a timestamp to refresh on a schedule",
+				Optional:            true,
+			},
+			"filename": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the file holding the extracted content",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the extracted content",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *KnowledgeSourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["knowledge"].(*knowledge.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *knowledge.Client, got: %T. Please report this issue to the provider developers.", clients["knowledge"]),
+		)
+		return
+	}
+
+	filesClient, ok := clients["files"].(*files.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *files.Client, got: %T. Please report this issue to the provider developers.", clients["files"]),
+		)
+		return
+	}
+
+	retrievalClient, ok := clients["retrieval"].(*retrieval.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *retrieval.Client, got: %T. Please report this issue to the provider developers.", clients["retrieval"]),
+		)
+		return
+	}
+
+	r.client = client
+	r.filesClient = filesClient
+	r.retrievalClient = retrievalClient
+}
+
+// ModifyPlan plans the file name from the configuration. The source is only
+// fetched again when it or the refresh trigger changed, and the file is only
+// replaced when its content or name may change.
+func (r *KnowledgeSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state KnowledgeSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filename := types.StringUnknown()
+	if !plan.Name.IsUnknown() && !plan.URL.IsUnknown() && !plan.YoutubeURL.IsUnknown() {
+		filename = types.StringValue(knowledgeSourceFilename(&plan))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("filename"), filename)...)
+
+	if !knowledgeSourceUnchanged(&plan, &state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	} else if !filename.Equal(state.Filename) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *KnowledgeSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KnowledgeSourceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filename := knowledgeSourceFilename(&data)
+	fileID, content, err := r.process(&data, filename)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to process knowledge source, got error: %s", err))
+		return
+	}
+
+	if err := r.attach(data.KnowledgeID.ValueString(), fileID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add knowledge source to knowledge base, got error: %s", err))
+		return
+	}
+
+	// Map response to model
+	data.ID = types.StringValue(fileID)
+	data.Filename = types.StringValue(filename)
+	data.ContentHash = types.StringValue(fileContentHash(content))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KnowledgeSourceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get knowledge base from API
+	result, err := r.client.Get(data.KnowledgeID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read knowledge base, got error: %s", err))
+		return
+	}
+
+	for _, file := range result.Files {
+		if file.ID == data.ID.ValueString() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	// The file was removed from the knowledge base outside of Terraform
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *KnowledgeSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state KnowledgeSourceResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	knowledgeID := data.KnowledgeID.ValueString()
+	filename := knowledgeSourceFilename(&data)
+
+	var fileID string
+	var content []byte
+	var err error
+	if knowledgeSourceUnchanged(&data, &state) {
+		// Renamed only; move the stored content to a file with the new name
+		content, err = r.storedContent(state.ID.ValueString())
+		if err == nil {
+			fileID, err = uploadProcessedFile(r.filesClient, filename, content)
+		}
+	} else {
+		fileID, content, err = r.process(&data, filename)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to process knowledge source, got error: %s", err))
+		return
+	}
+
+	hash := fileContentHash(content)
+	switch {
+	case hash == state.ContentHash.ValueString() && filename == state.Filename.ValueString():
+		// Unchanged content keeps the existing file
+		_ = r.filesClient.Delete(fileID)
+		fileID = state.ID.ValueString()
+	case hash == state.ContentHash.ValueString():
+		// OpenWebUI rejects duplicate content in a knowledge base, so the old
+		// file must go first. If the new one cannot be added, the state no
+		// longer points at a file and the next apply creates it again.
+		if _, err := r.client.RemoveFile(knowledgeID, state.ID.ValueString(), true); err != nil {
+			_ = r.filesClient.Delete(fileID)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove previous file from knowledge base, got error: %s", err))
+			return
+		}
+		if err := r.attach(knowledgeID, fileID); err != nil {
+			state.ID = types.StringValue("")
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add knowledge source to knowledge base, got error: %s", err))
+			return
+		}
+	default:
+		if err := r.attach(knowledgeID, fileID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add knowledge source to knowledge base, got error: %s", err))
+			return
+		}
+		if _, err := r.client.RemoveFile(knowledgeID, state.ID.ValueString(), true); err != nil {
+			data.ID = types.StringValue(fileID)
+			data.Filename = types.StringValue(filename)
+			data.ContentHash = types.StringValue(hash)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove previous file from knowledge base, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(fileID)
+	data.Filename = types.StringValue(filename)
+	data.ContentHash = types.StringValue(hash)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KnowledgeSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KnowledgeSourceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the file from the knowledge base and delete it
+	_, err := r.client.RemoveFile(data.KnowledgeID.ValueString(), data.ID.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove knowledge source from knowledge base, got error: %s", err))
+		return
+	}
+}
+
+// process creates the file holding the source and returns its ID and the
+// extracted text. The file is uploaded unprocessed and the source is processed
+// into the file's own collection, so that no collection is left behind: the
+// collection is replaced when the extracted text is stored as the content of
+// the file and deleted together with the file.
+func (r *KnowledgeSourceResource) process(data *KnowledgeSourceResourceModel, filename string) (string, []byte, error) {
+	source := data.URL
+	if source.IsNull() {
+		source = data.YoutubeURL
+	}
+	if source.IsNull() {
+		source = data.Text
+	}
+
+	file, err := r.filesClient.UploadUnprocessed(filename, []byte(source.ValueString()))
+	if err != nil {
+		return "", nil, err
+	}
+
+	content, err := r.extract(data, "file-"+file.ID)
+	if err == nil {
+		err = r.filesClient.UpdateContent(file.ID, string(content))
+	}
+	if err != nil {
+		_ = r.filesClient.Delete(file.ID)
+		return "", nil, err
+	}
+
+	return file.ID, content, nil
+}
+
+// extract processes the configured source into a collection with the
+// retrieval API and returns the extracted text.
+func (r *KnowledgeSourceResource) extract(data *KnowledgeSourceResourceModel, collectionName string) ([]byte, error) {
+	var result *retrieval.ProcessResponse
+	var err error
+	switch {
+	case !data.URL.IsNull():
+		result, err = r.retrievalClient.ProcessWeb(&retrieval.ProcessURLForm{URL: data.URL.ValueString(), CollectionName: collectionName})
+	case !data.YoutubeURL.IsNull():
+		result, err = r.retrievalClient.ProcessYoutube(&retrieval.ProcessURLForm{URL: data.YoutubeURL.ValueString(), CollectionName: collectionName})
+	default:
+		result, err = r.retrievalClient.ProcessText(&retrieval.ProcessTextForm{
+			Name:           data.Name.ValueString(),
+			Content:        data.Text.ValueString(),
+			CollectionName: collectionName,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := result.ExtractedContent()
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("no content could be extracted")
+	}
+
+	return []byte(content), nil
+}
+
+// attach adds a file to the knowledge base, deleting it if that fails.
+func (r *KnowledgeSourceResource) attach(knowledgeID, fileID string) error {
+	if _, err := r.client.AddFile(knowledgeID, fileID); err != nil {
+		_ = r.filesClient.Delete(fileID)
+		return err
+	}
+	return nil
+}
+
+// storedContent returns the extracted text stored in a file.
+func (r *KnowledgeSourceResource) storedContent(fileID string) ([]byte, error) {
+	file, err := r.filesClient.Get(fileID)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("file %s not found", fileID)
+	}
+
+	content, _ := file.Data["content"].(string)
+	if content == "" {
+		return nil, fmt.Errorf("file %s has no content", fileID)
+	}
+	return []byte(content), nil
+}
+
+// knowledgeSourceUnchanged reports whether the source and the refresh trigger
+// are the same in both models, i.e. the source need not be fetched again.
+func knowledgeSourceUnchanged(plan, state *KnowledgeSourceResourceModel) bool {
+	return plan.URL.Equal(state.URL) &&
+		plan.YoutubeURL.Equal(state.YoutubeURL) &&
+		plan.Text.Equal(state.Text) &&
+		plan.RefreshTrigger.Equal(state.RefreshTrigger)
+}
+
+// knowledgeSourceFilename derives a safe file name from the name or URL of a
+// source. A .txt extension is added so OpenWebUI loads the content as text.
+func knowledgeSourceFilename(data *KnowledgeSourceResourceModel) string {
+	if !data.Name.IsNull() {
+		filename := sanitizeKnowledgeSourceFilename(data.Name.ValueString())
+		if filepath.Ext(filename) == "" {
+			filename += ".txt"
+		}
+		return filename
+	}
+
+	source := data.URL.ValueString()
+	if data.URL.IsNull() {
+		source = data.YoutubeURL.ValueString()
+	}
+	source = strings.TrimPrefix(strings.TrimPrefix(source, "https://"), "http://")
+	return sanitizeKnowledgeSourceFilename(source) + ".txt"
+}
+
+// sanitizeKnowledgeSourceFilename replaces characters that are unsafe in file names.
+func sanitizeKnowledgeSourceFilename(name string) string {
+	return strings.Trim(knowledgeSourceFilenameUnsafe.ReplaceAllString(name, "_"), "_.")
+}
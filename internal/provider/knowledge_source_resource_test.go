@@ -0,0 +1,82 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/files"
+	"terraform-provider-openwebui/internal/provider/client/retrieval"
+)
+
+func TestKnowledgeSourceProcess(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/api/v1/files/":
+			json.NewEncoder(w).Encode(map[string]string{"id": "f1"})
+		case "/api/v1/retrieval/process/web":
+			var form retrieval.ProcessURLForm
+			json.NewDecoder(r.Body).Decode(&form)
+			if form.CollectionName != "file-f1" {
+				t.Errorf("Expected collection 'file-f1', got '%s'", form.CollectionName)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":          true,
+				"collection_name": form.CollectionName,
+				"file":            map[string]interface{}{"data": map[string]string{"content": "Page text"}},
+			})
+		case "/api/v1/files/f1/data/content/update":
+			var form files.ContentForm
+			json.NewDecoder(r.Body).Decode(&form)
+			if form.Content != "Page text" {
+				t.Errorf("Expected content 'Page text', got '%s'", form.Content)
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id": "f1"})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &KnowledgeSourceResource{
+		filesClient:     files.NewClient(server.URL, "token"),
+		retrievalClient: retrieval.NewClient(server.URL, "token"),
+	}
+	data := &KnowledgeSourceResourceModel{
+		URL:        types.StringValue("https://example.com/page"),
+		YoutubeURL: types.StringNull(),
+		Text:       types.StringNull(),
+		Name:       types.StringNull(),
+	}
+
+	fileID, content, err := r.process(data, "example.com_page.txt")
+	if err != nil {
+		t.Fatalf("process returned error: %v", err)
+	}
+	if fileID != "f1" || string(content) != "Page text" {
+		t.Errorf("Expected file 'f1' with 'Page text', got '%s' with '%s'", fileID, content)
+	}
+
+	expected := []string{
+		"POST /api/v1/files/?process=false",
+		"POST /api/v1/retrieval/process/web",
+		"POST /api/v1/files/f1/data/content/update",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Expected request %d to be '%s', got '%s'", i, expected[i], requests[i])
+		}
+	}
+}
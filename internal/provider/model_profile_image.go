@@ -0,0 +1,72 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxProfileImageSize is the largest profile image accepted, as the image is
+// stored inline in the model metadata
+const maxProfileImageSize = 1 << 20
+
+// readProfileImage reads a local PNG, JPEG or SVG profile image and returns
+// its content and content type.
+func readProfileImage(filename string) ([]byte, string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(content) > maxProfileImageSize {
+		return nil, "", fmt.Errorf("profile image is %d bytes, the maximum is %d bytes", len(content), maxProfileImageSize)
+	}
+
+	contentType, err := profileImageContentType(filename, content)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, contentType, nil
+}
+
+// profileImageContentType detects the type of a profile image from its
+// content, and from its extension for SVG which has no signature.
+func profileImageContentType(filename string, content []byte) (string, error) {
+	switch contentType := http.DetectContentType(content); contentType {
+	case "image/png", "image/jpeg":
+		return contentType, nil
+	}
+
+	if strings.EqualFold(filepath.Ext(filename), ".svg") && bytes.Contains(content, []byte("<svg")) {
+		return "image/svg+xml", nil
+	}
+	return "", fmt.Errorf("profile image must be a PNG, JPEG or SVG file")
+}
+
+// profileImageDataURI encodes a profile image as a data URI.
+func profileImageDataURI(content []byte, contentType string) string {
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(content))
+}
+
+// profileImageHash returns the hash of the image in a base64 data URI, or
+// null if the profile image is not such a data URI.
+func profileImageHash(url types.String) types.String {
+	header, data, ok := strings.Cut(url.ValueString(), ",")
+	if !ok || !strings.HasPrefix(header, "data:") || !strings.HasSuffix(header, ";base64") {
+		return types.StringNull()
+	}
+
+	content, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(fileContentHash(content))
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadProfileImage(t *testing.T) {
+	dir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	files := map[string][]byte{
+		"image.png": png,
+		"image.jpg": []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"),
+		"image.svg": []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+		"image.txt": []byte("not an image"),
+		"fake.svg":  []byte("not an image"),
+		"large.png": append(append([]byte{}, png...), make([]byte, maxProfileImageSize)...),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		file        string
+		contentType string
+	}{
+		{"image.png", "image/png"},
+		{"image.jpg", "image/jpeg"},
+		{"image.svg", "image/svg+xml"},
+		{"image.txt", ""},
+		{"fake.svg", ""},
+		{"large.png", ""},
+		{"missing.png", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.file, func(t *testing.T) {
+			content, contentType, err := readProfileImage(filepath.Join(dir, c.file))
+			if c.contentType == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got content type %q", contentType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if contentType != c.contentType {
+				t.Errorf("content type = %q, want %q", contentType, c.contentType)
+			}
+			if !bytes.Equal(content, files[c.file]) {
+				t.Errorf("content = %q, want %q", content, files[c.file])
+			}
+		})
+	}
+}
+
+func TestProfileImageHash(t *testing.T) {
+	content := []byte("\x89PNG\r\n\x1a\n")
+	uri := profileImageDataURI(content, "image/png")
+
+	if got, want := profileImageHash(types.StringValue(uri)), types.StringValue(fileContentHash(content)); !got.Equal(want) {
+		t.Errorf("profileImageHash(%q) = %s, want %s", uri, got, want)
+	}
+
+	for _, url := range []string{"/static/favicon.png", "https://example.com/image.png", "data:image/png,raw", "data:image/png;base64,%%%"} {
+		if got := profileImageHash(types.StringValue(url)); !got.IsNull() {
+			t.Errorf("profileImageHash(%q) = %s, want null", url, got)
+		}
+	}
+}
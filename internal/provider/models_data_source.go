@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,6 +29,13 @@ type ModelDataSource struct {
 	client *models.Client
 }
 
+// ModelDataSourceModel extends the model with the profile image it serves
+type ModelDataSourceModel struct {
+	models.Model
+	ProfileImage     types.String `tfsdk:"profile_image"`
+	ProfileImageHash types.String `tfsdk:"profile_image_hash"`
+}
+
 func (d *ModelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_model"
 }
@@ -92,10 +100,59 @@ func (d *ModelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "Random seed for reproducibility.",
 						Computed:    true,
 					},
-					"frequency_penalty": schema.Int64Attribute{
+					"frequency_penalty": schema.Float64Attribute{
 						Description: "Frequency penalty.",
 						Computed:    true,
 					},
+					"presence_penalty": schema.Float64Attribute{
+						Description: "Presence penalty.",
+						Computed:    true,
+					},
+					"repeat_penalty": schema.Float64Attribute{
+						Description: "Penalty for repeated tokens.",
+						Computed:    true,
+					},
+					"mirostat": schema.Int64Attribute{
+						Description: "Mirostat sampling mode (0 = disabled, 1 = Mirostat, 2 = Mirostat 2.0).",
+						Computed:    true,
+					},
+					"mirostat_eta": schema.Float64Attribute{
+						Description: "Mirostat learning rate.",
+						Computed:    true,
+					},
+					"mirostat_tau": schema.Float64Attribute{
+						Description: "Mirostat target entropy.",
+						Computed:    true,
+					},
+					"tfs_z": schema.Float64Attribute{
+						Description: "Tail free sampling parameter.",
+						Computed:    true,
+					},
+					"stop": schema.ListAttribute{
+						Description: "Stop sequences.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"num_thread": schema.Int64Attribute{
+						Description: "Number of CPU threads.",
+						Computed:    true,
+					},
+					"num_gpu": schema.Int64Attribute{
+						Description: "Number of layers offloaded to the GPU.",
+						Computed:    true,
+					},
+					"use_mmap": schema.BoolAttribute{
+						Description: "Whether to memory-map the model.",
+						Computed:    true,
+					},
+					"use_mlock": schema.BoolAttribute{
+						Description: "Whether to lock the model in memory.",
+						Computed:    true,
+					},
+					"keep_alive": schema.StringAttribute{
+						Description: "How long the model stays loaded after a request, e.g. '5m'.",
+						Computed:    true,
+					},
 					"repeat_last_n": schema.Int64Attribute{
 						Description: "Number of tokens to consider for repetition penalty.",
 						Computed:    true,
@@ -121,6 +178,11 @@ func (d *ModelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 				},
 			},
+			"extra_params": schema.StringAttribute{
+				Description: "Additional model parameters without a dedicated attribute, as a JSON object.",
+				CustomType:  jsontypes.NormalizedType{},
+				Computed:    true,
+			},
 			"meta": schema.SingleNestedAttribute{
 				Description: "Model metadata.",
 				Computed:    true,
@@ -149,6 +211,18 @@ func (d *ModelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 								Description: "Whether the model supports citations.",
 								Computed:    true,
 							},
+							"web_search": schema.BoolAttribute{
+								Description: "Whether web search can be used with the model.",
+								Computed:    true,
+							},
+							"image_generation": schema.BoolAttribute{
+								Description: "Whether image generation can be used with the model.",
+								Computed:    true,
+							},
+							"code_interpreter": schema.BoolAttribute{
+								Description: "Whether the code interpreter can be used with the model.",
+								Computed:    true,
+							},
 						},
 					},
 					"tags": schema.ListNestedAttribute{
@@ -163,11 +237,51 @@ func (d *ModelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							},
 						},
 					},
+					"tool_ids": schema.ListAttribute{
+						Description: "List of tool IDs available to the model.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
 					"filter_ids": schema.ListAttribute{
 						Description: "List of filter IDs.",
 						Computed:    true,
 						ElementType: types.StringType,
 					},
+					"default_filter_ids": schema.ListAttribute{
+						Description: "List of filter IDs enabled by default.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"action_ids": schema.ListAttribute{
+						Description: "List of action function IDs shown on the model's messages.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"default_feature_ids": schema.ListAttribute{
+						Description: "Features enabled by default in new chats.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"knowledge": schema.ListNestedAttribute{
+						Description: "Knowledge bases and files the model retrieves from.",
+						Computed:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "ID of the knowledge base or file.",
+									Computed:    true,
+								},
+								"type": schema.StringAttribute{
+									Description: "Type of the item: 'collection' for a knowledge base or 'file' for a single file.",
+									Computed:    true,
+								},
+								"name": schema.StringAttribute{
+									Description: "Display name shown in the chat UI.",
+									Computed:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 			"access_control": schema.SingleNestedAttribute{
@@ -224,6 +338,14 @@ func (d *ModelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "Whether the model is private.",
 				Computed:    true,
 			},
+			"profile_image": schema.StringAttribute{
+				Description: "The profile image served for the model, as a data URI.",
+				Computed:    true,
+			},
+			"profile_image_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the profile image served for the model.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -255,7 +377,7 @@ func (d *ModelDataSource) Configure(_ context.Context, req datasource.ConfigureR
 }
 
 func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var config models.Model
+	var config ModelDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -268,9 +390,6 @@ func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		resp.Diagnostics.AddError("Error reading model", err.Error())
 		return
 	}
-	diags = resp.State.Set(ctx, foundModel)
-	resp.Diagnostics.Append(diags...)
-
 	if foundModel == nil {
 		resp.Diagnostics.AddError(
 			"Error reading model",
@@ -278,4 +397,17 @@ func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
+
+	image, contentType, err := d.client.GetProfileImage(config.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading model profile image", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &ModelDataSourceModel{
+		Model:            *foundModel,
+		ProfileImage:     types.StringValue(profileImageDataURI(image, contentType)),
+		ProfileImageHash: types.StringValue(fileContentHash(image)),
+	})
+	resp.Diagnostics.Append(diags...)
 }
@@ -5,34 +5,57 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-openwebui/internal/provider/client/functions"
 	"terraform-provider-openwebui/internal/provider/client/models"
+	"terraform-provider-openwebui/internal/provider/client/tools"
 )
 
 var (
-	_ resource.Resource                = &ModelResource{}
-	_ resource.ResourceWithImportState = &ModelResource{}
+	_ resource.Resource                   = &ModelResource{}
+	_ resource.ResourceWithImportState    = &ModelResource{}
+	_ resource.ResourceWithValidateConfig = &ModelResource{}
+	_ resource.ResourceWithModifyPlan     = &ModelResource{}
 )
 
+// modelFeatureIDs are the chat features a model can enable by default
+var modelFeatureIDs = []string{"web_search", "image_generation", "code_interpreter"}
+
 func NewModelResource() resource.Resource {
 	return &ModelResource{}
 }
 
 type ModelResource struct {
-	client *models.Client
+	client          *models.Client
+	toolsClient     *tools.Client
+	functionsClient *functions.Client
+}
+
+// ModelResourceModel extends the model with its profile image file
+type ModelResourceModel struct {
+	models.Model
+	ProfileImageFile types.String `tfsdk:"profile_image_file"`
+	ProfileImageHash types.String `tfsdk:"profile_image_hash"`
 }
 
 func (r *ModelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,7 +85,27 @@ func (r *ModelResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
+	toolsClient, ok := clients["tools"].(*tools.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *tools.Client, got: %T. Please report this issue to the provider developers.", clients["tools"]),
+		)
+		return
+	}
+
+	functionsClient, ok := clients["functions"].(*functions.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *functions.Client, got: %T. Please report this issue to the provider developers.", clients["functions"]),
+		)
+		return
+	}
+
 	r.client = client
+	r.toolsClient = toolsClient
+	r.functionsClient = functionsClient
 }
 
 func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -99,7 +142,19 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					"min_p":             types.Float64Type,
 					"max_tokens":        types.Int64Type,
 					"seed":              types.Int64Type,
-					"frequency_penalty": types.Int64Type,
+					"frequency_penalty": types.Float64Type,
+					"presence_penalty":  types.Float64Type,
+					"repeat_penalty":    types.Float64Type,
+					"mirostat":          types.Int64Type,
+					"mirostat_eta":      types.Float64Type,
+					"mirostat_tau":      types.Float64Type,
+					"tfs_z":             types.Float64Type,
+					"stop":              types.ListType{ElemType: types.StringType},
+					"num_thread":        types.Int64Type,
+					"num_gpu":           types.Int64Type,
+					"use_mmap":          types.BoolType,
+					"use_mlock":         types.BoolType,
+					"keep_alive":        types.StringType,
 					"repeat_last_n":     types.Int64Type,
 					"num_ctx":           types.Int64Type,
 					"num_batch":         types.Int64Type,
@@ -115,7 +170,19 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					"min_p":             types.Float64Null(),
 					"max_tokens":        types.Int64Null(),
 					"seed":              types.Int64Null(),
-					"frequency_penalty": types.Int64Null(),
+					"frequency_penalty": types.Float64Null(),
+					"presence_penalty":  types.Float64Null(),
+					"repeat_penalty":    types.Float64Null(),
+					"mirostat":          types.Int64Null(),
+					"mirostat_eta":      types.Float64Null(),
+					"mirostat_tau":      types.Float64Null(),
+					"tfs_z":             types.Float64Null(),
+					"stop":              types.ListNull(types.StringType),
+					"num_thread":        types.Int64Null(),
+					"num_gpu":           types.Int64Null(),
+					"use_mmap":          types.BoolNull(),
+					"use_mlock":         types.BoolNull(),
+					"keep_alive":        types.StringNull(),
 					"repeat_last_n":     types.Int64Null(),
 					"num_ctx":           types.Int64Null(),
 					"num_batch":         types.Int64Null(),
@@ -162,10 +229,59 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Description: "Random seed for reproducibility.",
 						Optional:    true,
 					},
-					"frequency_penalty": schema.Int64Attribute{
+					"frequency_penalty": schema.Float64Attribute{
 						Description: "Frequency penalty.",
 						Optional:    true,
 					},
+					"presence_penalty": schema.Float64Attribute{
+						Description: "Presence penalty.",
+						Optional:    true,
+					},
+					"repeat_penalty": schema.Float64Attribute{
+						Description: "Penalty for repeated tokens.",
+						Optional:    true,
+					},
+					"mirostat": schema.Int64Attribute{
+						Description: "Mirostat sampling mode (0 = disabled, 1 = Mirostat, 2 = Mirostat 2.0).",
+						Optional:    true,
+					},
+					"mirostat_eta": schema.Float64Attribute{
+						Description: "Mirostat learning rate.",
+						Optional:    true,
+					},
+					"mirostat_tau": schema.Float64Attribute{
+						Description: "Mirostat target entropy.",
+						Optional:    true,
+					},
+					"tfs_z": schema.Float64Attribute{
+						Description: "Tail free sampling parameter.",
+						Optional:    true,
+					},
+					"stop": schema.ListAttribute{
+						Description: "Stop sequences.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"num_thread": schema.Int64Attribute{
+						Description: "Number of CPU threads.",
+						Optional:    true,
+					},
+					"num_gpu": schema.Int64Attribute{
+						Description: "Number of layers offloaded to the GPU.",
+						Optional:    true,
+					},
+					"use_mmap": schema.BoolAttribute{
+						Description: "Whether to memory-map the model.",
+						Optional:    true,
+					},
+					"use_mlock": schema.BoolAttribute{
+						Description: "Whether to lock the model in memory.",
+						Optional:    true,
+					},
+					"keep_alive": schema.StringAttribute{
+						Description: "How long the model stays loaded after a request, e.g. '5m'.",
+						Optional:    true,
+					},
 					"repeat_last_n": schema.Int64Attribute{
 						Description: "Number of tokens to consider for repetition penalty.",
 						Optional:    true,
@@ -191,6 +307,29 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"extra_params": schema.StringAttribute{
+				Description: "Additional model parameters without a dedicated attribute, as a JSON object. " +
+					"Parameters set outside of Terraform are kept when unset; set to \"{}\" to remove them.",
+				CustomType: jsontypes.NormalizedType{},
+				Optional:   true,
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"profile_image_file": schema.StringAttribute{
+				Description: "Path of a local PNG, JPEG or SVG file of at most 1 MiB to use as the profile image. " +
+					"It is stored as a data URI in meta.profile_image_url and diffed by hash.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("meta")),
+					stringvalidator.ConflictsWith(path.MatchRoot("meta").AtName("profile_image_url")),
+				},
+			},
+			"profile_image_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the profile image set from profile_image_file.",
+				Computed:    true,
+			},
 			"meta": schema.SingleNestedAttribute{
 				Description: "Model metadata.",
 				Optional:    true,
@@ -221,6 +360,30 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 								Description: "Whether the model supports citations.",
 								Optional:    true,
 							},
+							"web_search": schema.BoolAttribute{
+								Description: "Whether web search can be used with the model. Keeps the current value when unset.",
+								Optional:    true,
+								Computed:    true,
+								PlanModifiers: []planmodifier.Bool{
+									boolplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"image_generation": schema.BoolAttribute{
+								Description: "Whether image generation can be used with the model. Keeps the current value when unset.",
+								Optional:    true,
+								Computed:    true,
+								PlanModifiers: []planmodifier.Bool{
+									boolplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"code_interpreter": schema.BoolAttribute{
+								Description: "Whether the code interpreter can be used with the model. Keeps the current value when unset.",
+								Optional:    true,
+								Computed:    true,
+								PlanModifiers: []planmodifier.Bool{
+									boolplanmodifier.UseStateForUnknown(),
+								},
+							},
 						},
 					},
 					"tags": schema.ListNestedAttribute{
@@ -235,11 +398,59 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							},
 						},
 					},
+					"tool_ids": schema.SetAttribute{
+						Description: "List of tool IDs available to the model.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
 					"filter_ids": schema.SetAttribute{
 						Description: "List of filter IDs.",
 						Optional:    true,
 						ElementType: types.StringType,
 					},
+					"default_filter_ids": schema.SetAttribute{
+						Description: "List of filter IDs enabled by default. Each must also be in filter_ids.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"action_ids": schema.SetAttribute{
+						Description: "List of action function IDs shown on the model's messages.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"default_feature_ids": schema.SetAttribute{
+						Description: "Features enabled by default in new chats: 'web_search', 'image_generation' or 'code_interpreter'.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(modelFeatureIDs...)),
+						},
+					},
+					"knowledge": schema.ListNestedAttribute{
+						Description: "Knowledge bases and files the model retrieves from.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "ID of the knowledge base or file.",
+									Required:    true,
+								},
+								"type": schema.StringAttribute{
+									Description: "Type of the item: 'collection' for a knowledge base or 'file' for a single file.",
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString("collection"),
+									Validators: []validator.String{
+										stringvalidator.OneOf("collection", "file"),
+									},
+								},
+								"name": schema.StringAttribute{
+									Description: "Display name shown in the chat UI.",
+									Optional:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 			"access_control": schema.SingleNestedAttribute{
@@ -308,31 +519,50 @@ func (r *ModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 }
 
 func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan models.Model
+	var plan ModelResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	model, err := r.client.CreateModel(&plan)
+	resp.Diagnostics.Append(applyProfileImageFile(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, err := r.client.CreateModel(&plan.Model)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating model", err.Error())
 		return
 	}
 
+	// is_active is ignored on create and changed through the toggle endpoint.
+	// A failed toggle still saves the model, so the next apply retries it.
+	if !model.ID.IsNull() && model.IsActive.ValueBool() != plan.IsActive.ValueBool() {
+		if toggled, err := r.client.ToggleModel(model.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error toggling model", err.Error())
+		} else {
+			model = toggled
+		}
+	}
+
 	// Ensure the ID is set in the state
 	if model.ID.IsNull() {
 		resp.Diagnostics.AddError("Error creating model", "Model ID is null after creation")
 		return
 	}
 
-	diags = resp.State.Set(ctx, model)
+	diags = resp.State.Set(ctx, &ModelResourceModel{
+		Model:            *model,
+		ProfileImageFile: plan.ProfileImageFile,
+		ProfileImageHash: plan.ProfileImageHash,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *ModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state models.Model
+	var state ModelResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -350,19 +580,29 @@ func (r *ModelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		model.ID = state.ID
 	}
 
-	diags = resp.State.Set(ctx, model)
+	// Detect profile images changed outside of Terraform by their hash
+	newState := &ModelResourceModel{
+		Model:            *model,
+		ProfileImageFile: state.ProfileImageFile,
+		ProfileImageHash: types.StringNull(),
+	}
+	if !state.ProfileImageFile.IsNull() && model.Meta != nil {
+		newState.ProfileImageHash = profileImageHash(model.Meta.ProfileImageURL)
+	}
+
+	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *ModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan models.Model
+	var plan ModelResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state models.Model
+	var state ModelResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -372,23 +612,42 @@ func (r *ModelResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Ensure we use the existing ID for the update
 	plan.ID = state.ID
 
-	model, err := r.client.UpdateModel(state.ID.ValueString(), &plan)
+	resp.Diagnostics.Append(applyProfileImageFile(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, err := r.client.UpdateModel(state.ID.ValueString(), &plan.Model)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating model", err.Error())
 		return
 	}
 
+	// is_active is ignored on update and changed through the toggle endpoint.
+	// A failed toggle still saves the update, so the next apply retries it.
+	if model.IsActive.ValueBool() != plan.IsActive.ValueBool() {
+		if toggled, err := r.client.ToggleModel(state.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error toggling model", err.Error())
+		} else {
+			model = toggled
+		}
+	}
+
 	// Ensure the ID is preserved
 	if model.ID.IsNull() {
 		model.ID = state.ID
 	}
 
-	diags = resp.State.Set(ctx, model)
+	diags = resp.State.Set(ctx, &ModelResourceModel{
+		Model:            *model,
+		ProfileImageFile: plan.ProfileImageFile,
+		ProfileImageHash: plan.ProfileImageHash,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state models.Model
+	var state ModelResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -402,10 +661,220 @@ func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ModifyPlan checks the tool and function references so that invalid ones
+// fail the plan, and plans the profile image.
+func (r *ModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateReferences(ctx, req)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planProfileImage(ctx, req, resp)
+}
+
+// planProfileImage hashes the profile image file so that it is diffed by
+// hash. The data URI sent as profile_image_url is kept from the state while
+// the image is unchanged, and only known after apply otherwise.
+func planProfileImage(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var file types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("profile_image_file"), &file)...)
+	if resp.Diagnostics.HasError() || file.IsUnknown() {
+		return
+	}
+	if file.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("profile_image_hash"), types.StringNull())...)
+		return
+	}
+
+	content, _, err := readProfileImage(file.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("profile_image_file"), "Invalid Profile Image", err.Error())
+		return
+	}
+	hash := types.StringValue(fileContentHash(content))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("profile_image_hash"), hash)...)
+
+	url := types.StringUnknown()
+	if !req.State.Raw.IsNull() {
+		var stateHash, stateURL types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("profile_image_hash"), &stateHash)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("meta").AtName("profile_image_url"), &stateURL)...)
+		if stateHash.Equal(hash) {
+			url = stateURL
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("meta").AtName("profile_image_url"), url)...)
+}
+
+// applyProfileImageFile sets the profile image URL of the planned model to
+// the data URI of its profile image file, if any.
+func applyProfileImageFile(plan *ModelResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.ProfileImageFile.IsNull() || plan.Meta == nil {
+		return diags
+	}
+
+	content, contentType, err := readProfileImage(plan.ProfileImageFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("profile_image_file"), "Invalid Profile Image", err.Error())
+		return diags
+	}
+	plan.Meta.ProfileImageURL = types.StringValue(profileImageDataURI(content, contentType))
+	return diags
+}
+
 func (r *ModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func (r *ModelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var extraParams jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_params"), &extraParams)...)
+	if !extraParams.IsNull() && !extraParams.IsUnknown() {
+		var extra map[string]interface{}
+		if err := json.Unmarshal([]byte(extraParams.ValueString()), &extra); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("extra_params"), "Invalid Extra Params", "extra_params must be a JSON object.")
+		}
+		for key := range extra {
+			if models.IsTypedParam(key) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("extra_params"),
+					"Invalid Extra Params",
+					fmt.Sprintf("Parameter %s must be set in params.%s instead of extra_params.", key, key),
+				)
+			}
+		}
+	}
+
+	var filterIDs, defaultFilterIDs types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("meta").AtName("filter_ids"), &filterIDs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("meta").AtName("default_filter_ids"), &defaultFilterIDs)...)
+	if resp.Diagnostics.HasError() || filterIDs.IsUnknown() || defaultFilterIDs.IsUnknown() {
+		return
+	}
+
+	// OpenWebUI only applies default filters that are also assigned to the model
+	for _, id := range defaultFilterIDs.Elements() {
+		if !id.IsUnknown() && !setContains(filterIDs, id) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("meta").AtName("default_filter_ids"),
+				"Invalid Default Filter",
+				fmt.Sprintf("Default filter %s must also be listed in meta.filter_ids.", id),
+			)
+		}
+	}
+}
+
+// setContains reports whether a set contains a value.
+func setContains(set types.Set, value attr.Value) bool {
+	for _, element := range set.Elements() {
+		if element.Equal(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateReferences checks that the tools, filters and actions bound to a
+// model exist and that filters and actions are functions of the right type.
+// References are only checked when they changed, and unknown IDs are skipped
+// as they are not known until apply.
+func (r *ModelResource) validateReferences(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	references := []struct {
+		attribute    string
+		functionType string
+		ids          []types.String
+	}{
+		{attribute: "tool_ids"},
+		{attribute: "filter_ids", functionType: "filter"},
+		{attribute: "default_filter_ids", functionType: "filter"},
+		{attribute: "action_ids", functionType: "action"},
+	}
+
+	var checkTools, checkFunctions bool
+	for i := range references {
+		attribute := path.Root("meta").AtName(references[i].attribute)
+
+		var planIDs, stateIDs types.Set
+		diags.Append(req.Plan.GetAttribute(ctx, attribute, &planIDs)...)
+		if !req.State.Raw.IsNull() {
+			diags.Append(req.State.GetAttribute(ctx, attribute, &stateIDs)...)
+		}
+		if diags.HasError() {
+			return diags
+		}
+		if planIDs.IsNull() || planIDs.IsUnknown() || planIDs.Equal(stateIDs) {
+			continue
+		}
+
+		for _, element := range planIDs.Elements() {
+			if id, ok := element.(types.String); ok && !id.IsUnknown() && !id.IsNull() {
+				references[i].ids = append(references[i].ids, id)
+			}
+		}
+		if len(references[i].ids) > 0 {
+			checkTools = checkTools || references[i].functionType == ""
+			checkFunctions = checkFunctions || references[i].functionType != ""
+		}
+	}
+
+	if checkTools {
+		toolList, err := r.toolsClient.List()
+		if err != nil {
+			diags.AddError("Error listing tools", err.Error())
+			return diags
+		}
+		toolIDs := make(map[string]bool, len(toolList))
+		for _, tool := range toolList {
+			toolIDs[tool.ID] = true
+		}
+
+		for _, id := range references[0].ids {
+			// Tool server connections are referenced as "server:<id>"
+			if strings.HasPrefix(id.ValueString(), "server:") {
+				continue
+			}
+			if !toolIDs[id.ValueString()] {
+				diags.AddAttributeError(path.Root("meta").AtName("tool_ids"), "Invalid Tool Reference", fmt.Sprintf("Tool %s does not exist.", id.ValueString()))
+			}
+		}
+	}
+
+	if !checkFunctions {
+		return diags
+	}
+
+	functionList, err := r.functionsClient.List()
+	if err != nil {
+		diags.AddError("Error listing functions", err.Error())
+		return diags
+	}
+	functionTypes := make(map[string]string, len(functionList))
+	for _, function := range functionList {
+		functionTypes[function.ID] = function.Type
+	}
+
+	for _, reference := range references[1:] {
+		for _, id := range reference.ids {
+			functionType, ok := functionTypes[id.ValueString()]
+			if !ok {
+				diags.AddAttributeError(path.Root("meta").AtName(reference.attribute), "Invalid Function Reference", fmt.Sprintf("Function %s does not exist.", id.ValueString()))
+			} else if functionType != reference.functionType {
+				diags.AddAttributeError(path.Root("meta").AtName(reference.attribute), "Invalid Function Reference", fmt.Sprintf("Function %s is of type %s, expected %s.", id.ValueString(), functionType, reference.functionType))
+			}
+		}
+	}
+
+	return diags
+}
+
 // AccessControlDefaultModifier is a custom plan modifier for the access_control attribute.
 type AccessControlDefaultModifier struct{}
 
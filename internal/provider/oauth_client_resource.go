@@ -0,0 +1,167 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/configs"
+)
+
+var (
+	_ resource.Resource = &OAuthClientResource{}
+)
+
+func NewOAuthClientResource() resource.Resource {
+	return &OAuthClientResource{}
+}
+
+type OAuthClientResource struct {
+	client *configs.Client
+}
+
+func (r *OAuthClientResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_client"
+}
+
+func (r *OAuthClientResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["configs"].(*configs.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *configs.Client, got: %T. Please report this issue to the provider developers.", clients["configs"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OAuthClientResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Registers an OAuth 2.1 client for a tool server using dynamic client registration. " +
+			"Reference `client_id` and `oauth_client_info` from the `info` block of an `openwebui_tool_servers_config` connection with `auth_type = \"oauth_2.1\"`. " +
+			"OpenWebUI has no endpoint to read or revoke registrations, so any change re-registers the client and destroy only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The server-side OAuth client identifier, '<type>:<client_id>' (or just the client ID when type is empty).",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"url": schema.StringAttribute{
+				Description:   "The URL of the tool server to register the client with.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"client_id": schema.StringAttribute{
+				Description:   "The client ID to register. Must match the `info.id` of the tool server connection that uses it.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"client_name": schema.StringAttribute{
+				Description:   "Human readable name of the client presented to the authorization server.",
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"type": schema.StringAttribute{
+				Description:   "The tool server type the client is registered for (e.g. 'mcp').",
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("mcp"),
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"oauth_client_info": schema.StringAttribute{
+				Description:   "The encrypted client registration returned by OpenWebUI.",
+				Computed:      true,
+				Sensitive:     true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *OAuthClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan configs.OAuthClient
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	form := &configs.APIOAuthClientRegistrationForm{
+		URL:      plan.URL.ValueString(),
+		ClientID: plan.ClientID.ValueString(),
+	}
+	if !plan.ClientName.IsNull() {
+		form.ClientName = plan.ClientName.ValueStringPointer()
+	}
+
+	registration, err := r.client.RegisterOAuthClient(form, plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error registering OAuth client", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ClientID.ValueString())
+	if plan.Type.ValueString() != "" {
+		plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.Type.ValueString(), plan.ClientID.ValueString()))
+	}
+	plan.OAuthClientInfo = types.StringValue(registration.OAuthClientInfo)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OAuthClientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Registrations are not readable from the API; keep the prior state
+	var state configs.OAuthClient
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OAuthClientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement, so only computed values carry over
+	var plan configs.OAuthClient
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *OAuthClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// OpenWebUI has no endpoint to revoke a registration; removing it from
+	// state is sufficient. The client stops being used once no tool server
+	// connection references it.
+}
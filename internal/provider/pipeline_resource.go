@@ -0,0 +1,375 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/pipelines"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PipelineResource{}
+var _ resource.ResourceWithModifyPlan = &PipelineResource{}
+var _ resource.ResourceWithImportState = &PipelineResource{}
+
+func NewPipelineResource() resource.Resource {
+	return &PipelineResource{}
+}
+
+// PipelineResource defines the resource implementation.
+type PipelineResource struct {
+	client *pipelines.Client
+}
+
+// PipelineResourceModel describes the resource data model.
+type PipelineResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	URLIdx      types.Int64  `tfsdk:"url_idx"`
+	SourceURL   types.String `tfsdk:"source_url"`
+	SourceFile  types.String `tfsdk:"source_file"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	PipelineID  types.String `tfsdk:"pipeline_id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+}
+
+// requiresReplaceIfManaged replaces the pipeline when the value changes,
+// except when it was not known yet, e.g. after an import.
+var requiresReplaceIfManaged = stringplanmodifier.RequiresReplaceIf(
+	func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !req.StateValue.IsNull()
+	},
+	"Reinstalls the pipeline when the value changes.",
+	"Reinstalls the pipeline when the value changes.",
+)
+
+func (r *PipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Installs a pipeline on the pipelines server behind an OpenAI API connection of OpenWebUI. " +
+			"The pipelines server derives the pipeline ID from the file name. Any change reinstalls the pipeline.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Pipeline identifier, `<url_idx>/<pipeline_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url_idx": schema.Int64Attribute{
+				MarkdownDescription: "Index of the OpenAI API connection served by the pipelines server. Defaults to `0`",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"source_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the pipeline Python file, downloaded by the pipelines server. Exactly one of `source_url` or `source_file` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source_file")),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged,
+				},
+			},
+			"source_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the local pipeline `.py` file to upload",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged,
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of `source_file`, used to reinstall the pipeline when the file changes",
+				Computed:            true,
+			},
+			"pipeline_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the pipeline on the pipelines server",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the pipeline",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the pipeline, e.g. `filter` or `pipe`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["pipelines"].(*pipelines.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pipelines.Client, got: %T. Please report this issue to the provider developers.", clients["pipelines"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to hash on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PipelineResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SourceFile.IsUnknown() {
+		return
+	}
+
+	hash := types.StringNull()
+	if !plan.SourceFile.IsNull() {
+		content, err := os.ReadFile(plan.SourceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Unable to Read File", err.Error())
+			return
+		}
+		hash = types.StringValue(fileContentHash(content))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state PipelineResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reinstall the pipeline when the local file changes
+	if !state.ContentHash.IsNull() && !hash.Equal(state.ContentHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+	}
+}
+
+func (r *PipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PipelineResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	urlIdx := data.URLIdx.ValueInt64()
+	var pipelineID string
+	if !data.SourceFile.IsNull() {
+		content, err := os.ReadFile(data.SourceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Unable to Read File", err.Error())
+			return
+		}
+
+		filename := filepath.Base(data.SourceFile.ValueString())
+		if err := r.client.Upload(filename, content, urlIdx); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload pipeline, got error: %s", err))
+			return
+		}
+		pipelineID = pipelineIDFromFilename(filename)
+	} else {
+		if err := r.client.Add(data.SourceURL.ValueString(), urlIdx); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add pipeline, got error: %s", err))
+			return
+		}
+
+		sourceURL, err := url.Parse(data.SourceURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_url"), "Invalid URL", err.Error())
+			return
+		}
+		pipelineID = pipelineIDFromFilename(filepath.Base(sourceURL.Path))
+	}
+
+	pipeline, err := r.client.Get(pipelineID, urlIdx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read pipeline, got error: %s", err))
+		return
+	}
+	if pipeline == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Pipeline %s was installed but is not listed by the pipelines server", pipelineID))
+		return
+	}
+
+	data.ID = types.StringValue(pipelineResourceID(urlIdx, pipelineID))
+	data.PipelineID = types.StringValue(pipelineID)
+	data.Name = types.StringValue(pipeline.Name)
+	data.Type = types.StringValue(pipeline.Type)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PipelineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pipeline, err := r.client.Get(data.PipelineID.ValueString(), data.URLIdx.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read pipeline, got error: %s", err))
+		return
+	}
+	if pipeline == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Name = types.StringValue(pipeline.Name)
+	data.Type = types.StringValue(pipeline.Type)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PipelineResourceModel
+
+	// Every change reinstalls the pipeline, except adopting the source of an
+	// imported pipeline which only needs to be recorded
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PipelineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Delete(data.PipelineID.ValueString(), data.URLIdx.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete pipeline, got error: %s", err))
+		return
+	}
+}
+
+func (r *PipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pipelineID, urlIdx, err := parsePipelineResourceID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("url_idx"), urlIdx)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipeline_id"), pipelineID)...)
+}
+
+// pipelineIDFromFilename returns the ID the pipelines server gives to a
+// pipeline installed from a file.
+func pipelineIDFromFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".py")
+}
+
+// pipelineResourceID returns the resource ID of a pipeline, which includes
+// the connection index as pipeline IDs are only unique per pipelines server.
+func pipelineResourceID(urlIdx int64, pipelineID string) string {
+	return fmt.Sprintf("%d/%s", urlIdx, pipelineID)
+}
+
+// parsePipelineResourceID parses a `<url_idx>/<pipeline_id>` resource ID.
+func parsePipelineResourceID(id string) (string, int64, error) {
+	idx, pipelineID, ok := strings.Cut(id, "/")
+	urlIdx, err := strconv.ParseInt(idx, 10, 64)
+	if !ok || err != nil || pipelineID == "" {
+		return "", 0, fmt.Errorf("expected <url_idx>/<pipeline_id>, got %q", id)
+	}
+	return pipelineID, urlIdx, nil
+}
+
+// pipelineValvesClient adapts the pipelines client to the valves resource,
+// with pipelines identified by their resource ID.
+type pipelineValvesClient struct {
+	client *pipelines.Client
+}
+
+func (c pipelineValvesClient) GetValves(id string) (map[string]interface{}, error) {
+	pipelineID, urlIdx, err := parsePipelineResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.GetValves(pipelineID, urlIdx)
+}
+
+func (c pipelineValvesClient) GetValvesSpec(id string) (map[string]interface{}, error) {
+	pipelineID, urlIdx, err := parsePipelineResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.GetValvesSpec(pipelineID, urlIdx)
+}
+
+func (c pipelineValvesClient) UpdateValves(id string, values map[string]interface{}) (map[string]interface{}, error) {
+	pipelineID, urlIdx, err := parsePipelineResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.UpdateValves(pipelineID, urlIdx, values)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestParsePipelineResourceID(t *testing.T) {
+	pipelineID, urlIdx, err := parsePipelineResourceID(pipelineResourceID(2, "rate_limit_filter"))
+	if err != nil {
+		t.Fatalf("parsePipelineResourceID returned error: %v", err)
+	}
+	if pipelineID != "rate_limit_filter" || urlIdx != 2 {
+		t.Errorf("Expected rate_limit_filter on connection 2, got %s on connection %d", pipelineID, urlIdx)
+	}
+
+	for _, id := range []string{"rate_limit_filter", "x/rate_limit_filter", "1/"} {
+		if _, _, err := parsePipelineResourceID(id); err == nil {
+			t.Errorf("Expected an error for %q", id)
+		}
+	}
+
+	if got := pipelineIDFromFilename("rate_limit_filter.py"); got != "rate_limit_filter" {
+		t.Errorf("Expected rate_limit_filter, got %s", got)
+	}
+}
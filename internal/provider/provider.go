@@ -8,11 +8,15 @@ import (
 	"os"
 
 	"terraform-provider-openwebui/internal/provider/client/configs"
+	"terraform-provider-openwebui/internal/provider/client/evaluations"
+	"terraform-provider-openwebui/internal/provider/client/files"
 	"terraform-provider-openwebui/internal/provider/client/functions"
 	"terraform-provider-openwebui/internal/provider/client/groups"
 	"terraform-provider-openwebui/internal/provider/client/knowledge"
 	"terraform-provider-openwebui/internal/provider/client/models"
+	"terraform-provider-openwebui/internal/provider/client/pipelines"
 	"terraform-provider-openwebui/internal/provider/client/prompts"
+	"terraform-provider-openwebui/internal/provider/client/retrieval"
 	"terraform-provider-openwebui/internal/provider/client/tools"
 	"terraform-provider-openwebui/internal/provider/client/users"
 
@@ -110,17 +114,25 @@ func (p *OpenWebUIProvider) Configure(ctx context.Context, req provider.Configur
 	functionsClient := functions.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
 	promptsClient := prompts.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
 	configsClient := configs.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
+	evaluationsClient := evaluations.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
+	filesClient := files.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
+	retrievalClient := retrieval.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
+	pipelinesClient := pipelines.NewClient(config.Endpoint.ValueString(), config.Token.ValueString())
 
 	// Create a map to store all clients
 	clients := map[string]interface{}{
-		"groups":    groupsClient,
-		"knowledge": knowledgeClient,
-		"models":    modelsClient,
-		"users":     usersClient,
-		"tools":     toolsClient,
-		"functions": functionsClient,
-		"prompts":   promptsClient,
-		"configs":   configsClient,
+		"groups":      groupsClient,
+		"knowledge":   knowledgeClient,
+		"models":      modelsClient,
+		"users":       usersClient,
+		"tools":       toolsClient,
+		"functions":   functionsClient,
+		"prompts":     promptsClient,
+		"configs":     configsClient,
+		"evaluations": evaluationsClient,
+		"files":       filesClient,
+		"retrieval":   retrievalClient,
+		"pipelines":   pipelinesClient,
 	}
 
 	resp.DataSourceData = clients
@@ -136,6 +148,7 @@ func (p *OpenWebUIProvider) DataSources(_ context.Context) []func() datasource.D
 		NewToolDataSource,
 		NewFunctionDataSource,
 		NewPromptDataSource,
+		NewFilesDataSource,
 	}
 }
 
@@ -143,13 +156,28 @@ func (p *OpenWebUIProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewGroupResource,
 		NewKnowledgeResource,
+		NewKnowledgeFileResource,
+		NewKnowledgeDirectoryResource,
+		NewKnowledgeSourceResource,
+		NewKnowledgeReindexResource,
+		NewFileResource,
 		NewModelResource,
 		NewToolResource,
 		NewFunctionResource,
+		NewFunctionValvesResource,
+		NewToolsBundleResource,
+		NewFunctionsBundleResource,
+		NewModelsBundleResource,
+		NewPipelineResource,
+		NewPipelineValvesResource,
+		NewToolValvesResource,
 		NewPromptResource,
 		NewConnectionsConfigResource,
 		NewToolServersConfigResource,
+		NewToolServerConnectionResource,
 		NewModelsConfigResource,
+		NewEvaluationConfigResource,
+		NewOAuthClientResource,
 	}
 }
 
@@ -0,0 +1,175 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+)
+
+// frontmatterPattern matches a `key: value` line of a frontmatter docstring
+var frontmatterPattern = regexp.MustCompile(`(?i)^\s*([a-z_]+):\s*(.*)\s*$`)
+
+// sourceLoader loads code from a URL through OpenWebUI
+type sourceLoader func(url string) (string, error)
+
+// sourceContentAttributes returns the schema attributes used to provide the
+// code of a tool or function inline, from a local file or from a URL.
+func sourceContentAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"content": schema.StringAttribute{
+			Description: fmt.Sprintf("The content/code of the %s. Exactly one of content, source_file or source_url must be set.", kind),
+			Optional:    true,
+			Computed:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("source_file"), path.MatchRoot("source_url")),
+			},
+		},
+		"source_file": schema.StringAttribute{
+			Description: fmt.Sprintf("Path of a local Python file holding the code of the %s.", kind),
+			Optional:    true,
+		},
+		"source_url": schema.StringAttribute{
+			Description: fmt.Sprintf("URL to load the code of the %s from, e.g. a GitHub file or an OpenWebUI community page.", kind),
+			Optional:    true,
+		},
+		"content_hash": schema.StringAttribute{
+			Description: "SHA-256 hash of the code. Changes of the source file, the URL content or the code in OpenWebUI show up as a diff.",
+			Computed:    true,
+		},
+	}
+}
+
+// planSourceContent resolves the configured code source at plan time and
+// plans content and content_hash from it, as well as the attributes defaulted
+// from its frontmatter.
+func planSourceContent(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, load sourceLoader) {
+	// Nothing to resolve on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var content, sourceFile, sourceURL types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_file"), &sourceFile)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_url"), &sourceURL)...)
+	if resp.Diagnostics.HasError() || content.IsUnknown() || sourceFile.IsUnknown() || sourceURL.IsUnknown() {
+		return
+	}
+
+	code, diags := resolveSourceContent(content, sourceFile, sourceURL, load)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content"), code)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), fileContentHash([]byte(code)))...)
+	resp.Diagnostics.Append(planFrontmatter(ctx, req.Config, &resp.Plan, code)...)
+}
+
+// planFrontmatter defaults name, meta.description and meta.manifest from the
+// frontmatter of the code when they are not configured. OpenWebUI stores the
+// frontmatter as the manifest itself, so planning it avoids spurious diffs.
+func planFrontmatter(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, code string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	frontmatter := parseFrontmatter(code)
+
+	var name, description types.String
+	var manifest types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("meta").AtName("description"), &description)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("meta").AtName("manifest"), &manifest)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if name.IsNull() {
+		title, ok := frontmatter["title"]
+		if !ok {
+			diags.AddAttributeError(path.Root("name"), "Missing Name", "name must be set when the frontmatter of the code has no title.")
+			return diags
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("name"), title)...)
+	}
+
+	if description.IsNull() {
+		value := types.StringNull()
+		if frontmatter["description"] != "" {
+			value = types.StringValue(frontmatter["description"])
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("meta").AtName("description"), value)...)
+	}
+
+	if manifest.IsNull() {
+		value := types.MapNull(types.StringType)
+		if len(frontmatter) > 0 {
+			var d diag.Diagnostics
+			value, d = types.MapValueFrom(ctx, types.StringType, frontmatter)
+			diags.Append(d...)
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("meta").AtName("manifest"), value)...)
+	}
+
+	return diags
+}
+
+// parseFrontmatter parses the `key: value` lines of the docstring that opens
+// the code of a tool or function, the same way OpenWebUI does.
+func parseFrontmatter(code string) map[string]string {
+	frontmatter := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(lines[0]) != `"""` {
+		return frontmatter
+	}
+
+	for _, line := range lines[1:] {
+		if strings.Contains(line, `"""`) {
+			break
+		}
+		if match := frontmatterPattern.FindStringSubmatch(line); match != nil {
+			frontmatter[strings.TrimSpace(match[1])] = strings.TrimSpace(match[2])
+		}
+	}
+
+	return frontmatter
+}
+
+// resolveSourceContent returns the code from whichever source is set.
+func resolveSourceContent(content, sourceFile, sourceURL types.String, load sourceLoader) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !sourceFile.IsNull():
+		code, err := os.ReadFile(sourceFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("source_file"), "Unable to Read File", err.Error())
+			return "", diags
+		}
+		return string(code), diags
+	case !sourceURL.IsNull():
+		code, err := load(sourceURL.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("source_url"), "Unable to Load URL", err.Error())
+			return "", diags
+		}
+		return code, diags
+	default:
+		return content.ValueString(), diags
+	}
+}
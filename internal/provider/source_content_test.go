@@ -0,0 +1,86 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveSourceContent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tool.py")
+	if err := os.WriteFile(file, []byte("class Tools: pass"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	load := func(url string) (string, error) {
+		if url == "https://example.com/missing.py" {
+			return "", fmt.Errorf("not found")
+		}
+		return "loaded from " + url, nil
+	}
+
+	cases := []struct {
+		name       string
+		content    types.String
+		sourceFile types.String
+		sourceURL  types.String
+		want       string
+		wantErr    bool
+	}{
+		{"inline", types.StringValue("inline"), types.StringNull(), types.StringNull(), "inline", false},
+		{"file", types.StringNull(), types.StringValue(file), types.StringNull(), "class Tools: pass", false},
+		{"missing file", types.StringNull(), types.StringValue(file + ".missing"), types.StringNull(), "", true},
+		{"url", types.StringNull(), types.StringNull(), types.StringValue("https://example.com/tool.py"), "loaded from https://example.com/tool.py", false},
+		{"failing url", types.StringNull(), types.StringNull(), types.StringValue("https://example.com/missing.py"), "", true},
+	}
+
+	for _, c := range cases {
+		got, diags := resolveSourceContent(c.content, c.sourceFile, c.sourceURL, load)
+		if diags.HasError() != c.wantErr {
+			t.Errorf("%s: unexpected diagnostics: %v", c.name, diags)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseFrontmatter(t *testing.T) {
+	code := `"""
+title: Web Search
+author: open-webui
+version: 0.1.2
+requirements: requests, beautifulsoup4
+description: Search the web
+"""
+
+from pydantic import BaseModel
+
+"""
+ignored: true
+"""
+`
+
+	expected := map[string]string{
+		"title":        "Web Search",
+		"author":       "open-webui",
+		"version":      "0.1.2",
+		"requirements": "requests, beautifulsoup4",
+		"description":  "Search the web",
+	}
+	if got := parseFrontmatter(code); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := parseFrontmatter("import os\n\"\"\"\ntitle: Late\n\"\"\"\n"); len(got) != 0 {
+		t.Errorf("Expected no frontmatter, got %v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +21,7 @@ import (
 var (
 	_ resource.Resource                = &ToolResource{}
 	_ resource.ResourceWithImportState = &ToolResource{}
+	_ resource.ResourceWithModifyPlan  = &ToolResource{}
 )
 
 func NewToolResource() resource.Resource {
@@ -30,6 +32,14 @@ type ToolResource struct {
 	client *tools.Client
 }
 
+// ToolResourceModel extends the tool model with the sources of its code
+type ToolResourceModel struct {
+	tools.Tool
+	SourceFile  types.String `tfsdk:"source_file"`
+	SourceURL   types.String `tfsdk:"source_url"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
 func (r *ToolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_tool"
 }
@@ -73,29 +83,29 @@ func (r *ToolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "The name of the tool.",
-				Required:    true,
-			},
-			"content": schema.StringAttribute{
-				Description: "The content/code of the tool.",
-				Required:    true,
+				Description: "The name of the tool. Defaults to the title in the frontmatter of the code.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"specs": schema.StringAttribute{
-				Description: "Tool specifications as JSON. Handles arbitrary JSON structure with semantic equality (ignores whitespace/ordering differences).",
+				Description: "Tool specifications as JSON. Handles arbitrary JSON structure with semantic equality (ignores whitespace/ordering differences). When omitted, OpenWebUI generates the OpenAI function specs from the code.",
 				CustomType:  jsontypes.NormalizedType{},
 				Optional:    true,
+				Computed:    true,
 			},
 			"meta": schema.SingleNestedAttribute{
 				Description: "Tool metadata.",
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
 					"description": schema.StringAttribute{
-						Description: "Description of the tool.",
+						Description: "Description of the tool. Defaults to the description in the frontmatter of the code.",
 						Optional:    true,
+						Computed:    true,
 					},
 					"manifest": schema.MapAttribute{
-						Description: "Tool manifest metadata.",
+						Description: "Tool manifest metadata. Defaults to the frontmatter of the code, which OpenWebUI stores as the manifest.",
 						Optional:    true,
+						Computed:    true,
 						ElementType: types.StringType,
 					},
 				},
@@ -148,10 +158,58 @@ func (r *ToolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 		},
 	}
+
+	for name, attribute := range sourceContentAttributes("tool") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *ToolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planSourceContent(ctx, req, resp, func(url string) (string, error) {
+		source, err := r.client.LoadURL(url)
+		if err != nil {
+			return "", err
+		}
+		return source.Content, nil
+	})
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planToolSpecs(ctx, req, resp)...)
+}
+
+// planToolSpecs keeps the specs generated by OpenWebUI while the content is
+// unchanged, and marks them unknown when it changes as they are regenerated
+// from the code.
+func planToolSpecs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var configSpecs jsontypes.Normalized
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("specs"), &configSpecs)...)
+	if diags.HasError() || !configSpecs.IsNull() {
+		return diags
+	}
+
+	var planContent, stateContent types.String
+	var stateSpecs jsontypes.Normalized
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("content"), &planContent)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("content"), &stateContent)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("specs"), &stateSpecs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if planContent.IsUnknown() || !planContent.Equal(stateContent) {
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("specs"), jsontypes.NewNormalizedUnknown())...)
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("specs"), stateSpecs)...)
+	return diags
 }
 
 func (r *ToolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan tools.Tool
+	var plan ToolResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -236,7 +294,12 @@ func (r *ToolResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Convert API response back to Terraform model
-	state := tools.APIToTool(tool)
+	state := &ToolResourceModel{
+		Tool:        *tools.APIToTool(tool),
+		SourceFile:  plan.SourceFile,
+		SourceURL:   plan.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(tool.Content))),
+	}
 
 	// Ensure the ID is set in the state
 	if state.ID.IsNull() {
@@ -249,7 +312,7 @@ func (r *ToolResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *ToolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state tools.Tool
+	var state ToolResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -263,7 +326,12 @@ func (r *ToolResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Convert API response to Terraform model
-	newState := tools.APIToTool(tool)
+	newState := &ToolResourceModel{
+		Tool:        *tools.APIToTool(tool),
+		SourceFile:  state.SourceFile,
+		SourceURL:   state.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(tool.Content))),
+	}
 
 	// Ensure the ID is preserved
 	if newState.ID.IsNull() {
@@ -275,14 +343,14 @@ func (r *ToolResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *ToolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan tools.Tool
+	var plan ToolResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state tools.Tool
+	var state ToolResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -367,7 +435,12 @@ func (r *ToolResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Convert API response back to Terraform model
-	newState := tools.APIToTool(tool)
+	newState := &ToolResourceModel{
+		Tool:        *tools.APIToTool(tool),
+		SourceFile:  plan.SourceFile,
+		SourceURL:   plan.SourceURL,
+		ContentHash: types.StringValue(fileContentHash([]byte(tool.Content))),
+	}
 
 	// Ensure the ID is preserved
 	if newState.ID.IsNull() {
@@ -379,7 +452,7 @@ func (r *ToolResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *ToolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state tools.Tool
+	var state ToolResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,279 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/configs"
+)
+
+var (
+	_ resource.Resource                = &ToolServerConnectionResource{}
+	_ resource.ResourceWithImportState = &ToolServerConnectionResource{}
+	_ resource.ResourceWithModifyPlan  = &ToolServerConnectionResource{}
+)
+
+// toolServerConnectionsMu serializes the read-modify-write of the tool
+// server connections list, since several connection resources in the same
+// run update it concurrently.
+var toolServerConnectionsMu sync.Mutex
+
+func NewToolServerConnectionResource() resource.Resource {
+	return &ToolServerConnectionResource{}
+}
+
+type ToolServerConnectionResource struct {
+	client *configs.Client
+}
+
+func (r *ToolServerConnectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_server_connection"
+}
+
+func (r *ToolServerConnectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	client, ok := clients["configs"].(*configs.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *configs.Client, got: %T. Please report this issue to the provider developers.", clients["configs"]),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ToolServerConnectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := toolServerConnectionSchemaAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Description:   "Identifier of the connection, '<url>|<path>'.",
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	// The URL and path identify the entry in the list, so changing them moves
+	// the connection to a new entry
+	attributes["url"] = schema.StringAttribute{
+		Description:   "The URL of the tool server.",
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attributes["path"] = schema.StringAttribute{
+		Description:   "The path on the tool server.",
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a single OpenWebUI tool server connection, identified by URL and path. " +
+			"Connections not managed by this resource are left untouched, so several configurations can each register their own tool servers. " +
+			"Do not combine with `openwebui_tool_servers_config`, which owns the whole list.",
+		Attributes: attributes,
+	}
+}
+
+func (r *ToolServerConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan configs.ToolServerConnectionEntry
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiConn := toolServerConnectionToAPI(&plan.ToolServerConnection)
+	if err := r.upsertConnection(&apiConn); err != nil {
+		resp.Diagnostics.AddError("Error creating tool server connection", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(toolServerConnectionID(apiConn.URL, apiConn.Path))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ToolServerConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state configs.ToolServerConnectionEntry
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetToolServers()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading tool server connection", err.Error())
+		return
+	}
+
+	key := toolServerConnectionKey(state.URL.ValueString(), state.Path.ValueString())
+	for _, conn := range config.ToolServerConnections {
+		if toolServerConnectionKey(conn.URL, conn.Path) != key {
+			continue
+		}
+
+		newState := configs.ToolServerConnectionEntry{
+			ID:                   state.ID,
+			ToolServerConnection: configs.APIToToolServerConnection(&conn),
+		}
+		newState.Verify = state.Verify
+
+		diags = resp.State.Set(ctx, newState)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// The connection was removed outside of Terraform
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ToolServerConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan configs.ToolServerConnectionEntry
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiConn := toolServerConnectionToAPI(&plan.ToolServerConnection)
+	if err := r.upsertConnection(&apiConn); err != nil {
+		resp.Diagnostics.AddError("Error updating tool server connection", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ToolServerConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state configs.ToolServerConnectionEntry
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toolServerConnectionsMu.Lock()
+	defer toolServerConnectionsMu.Unlock()
+
+	config, err := r.client.GetToolServers()
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting tool server connection", err.Error())
+		return
+	}
+
+	key := toolServerConnectionKey(state.URL.ValueString(), state.Path.ValueString())
+	connections := make([]configs.APIToolServerConnection, 0, len(config.ToolServerConnections))
+	for _, conn := range config.ToolServerConnections {
+		if toolServerConnectionKey(conn.URL, conn.Path) != key {
+			connections = append(connections, conn)
+		}
+	}
+
+	_, err = r.client.UpdateToolServers(&configs.APIToolServersConfig{ToolServerConnections: connections})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting tool server connection", err.Error())
+		return
+	}
+}
+
+func (r *ToolServerConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to verify on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan configs.ToolServerConnectionEntry
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Verify.ValueBool() || !toolServerConnectionIsKnown(&plan.ToolServerConnection) {
+		return
+	}
+
+	apiConn := toolServerConnectionToAPI(&plan.ToolServerConnection)
+	if err := r.client.VerifyToolServer(&apiConn); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Tool Server Verification Failed",
+			fmt.Sprintf("OpenWebUI could not verify the tool server at %s: %s", apiConn.URL, err),
+		)
+	}
+}
+
+func (r *ToolServerConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID is "<url>|<path>"; the URL may not contain '|' but the path may be empty
+	idx := strings.LastIndex(req.ID, "|")
+	if idx <= 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID must be in the format '<url>|<path>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("url"), req.ID[:idx])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID[idx+1:])...)
+}
+
+// upsertConnection replaces the connection with the same URL and path, or
+// appends it, keeping every other connection as it is on the server.
+func (r *ToolServerConnectionResource) upsertConnection(apiConn *configs.APIToolServerConnection) error {
+	toolServerConnectionsMu.Lock()
+	defer toolServerConnectionsMu.Unlock()
+
+	config, err := r.client.GetToolServers()
+	if err != nil {
+		return err
+	}
+
+	connections := config.ToolServerConnections
+	key := toolServerConnectionKey(apiConn.URL, apiConn.Path)
+	found := false
+	for i, conn := range connections {
+		if toolServerConnectionKey(conn.URL, conn.Path) == key {
+			connections[i] = *apiConn
+			found = true
+			break
+		}
+	}
+	if !found {
+		connections = append(connections, *apiConn)
+	}
+
+	_, err = r.client.UpdateToolServers(&configs.APIToolServersConfig{ToolServerConnections: connections})
+	return err
+}
+
+// toolServerConnectionID builds the resource ID of a tool server connection.
+func toolServerConnectionID(url, path string) string {
+	return url + "|" + path
+}
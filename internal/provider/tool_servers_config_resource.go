@@ -7,20 +7,24 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-openwebui/internal/provider/client/configs"
+	"terraform-provider-openwebui/internal/provider/client/models"
 )
 
 var (
 	_ resource.Resource                = &ToolServersConfigResource{}
 	_ resource.ResourceWithImportState = &ToolServersConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &ToolServersConfigResource{}
 )
 
 func NewToolServersConfigResource() resource.Resource {
@@ -74,39 +78,130 @@ func (r *ToolServersConfigResource) Schema(_ context.Context, _ resource.SchemaR
 				Description: "List of tool server connections.",
 				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
+					Attributes: toolServerConnectionSchemaAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// toolServerConnectionSchemaAttributes returns the schema of a single tool
+// server connection, shared by the list and per-connection resources.
+func toolServerConnectionSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"url": schema.StringAttribute{
+			Description: "The URL of the tool server.",
+			Required:    true,
+		},
+		"path": schema.StringAttribute{
+			Description: "The path on the tool server.",
+			Required:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "The type of the tool server.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString("openapi"),
+		},
+		"auth_type": schema.StringAttribute{
+			Description: "The authentication type (e.g. 'bearer', 'session', 'none' or 'oauth_2.1').",
+			Optional:    true,
+		},
+		"key": schema.StringAttribute{
+			Description: "The authentication key.",
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"config": schema.SingleNestedAttribute{
+			Description: "Settings of the tool server connection.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"enable": schema.BoolAttribute{
+					Description: "Whether the tool server is enabled.",
+					Optional:    true,
+				},
+				"access_control": schema.SingleNestedAttribute{
+					Description: "Access control settings. The tool server is available to all users when unset.",
+					Optional:    true,
 					Attributes: map[string]schema.Attribute{
-						"url": schema.StringAttribute{
-							Description: "The URL of the tool server.",
-							Required:    true,
-						},
-						"path": schema.StringAttribute{
-							Description: "The path on the tool server.",
-							Required:    true,
-						},
-						"type": schema.StringAttribute{
-							Description: "The type of the tool server.",
-							Optional:    true,
-							Computed:    true,
-							Default:     stringdefault.StaticString("openapi"),
-						},
-						"auth_type": schema.StringAttribute{
-							Description: "The authentication type.",
-							Optional:    true,
-						},
-						"key": schema.StringAttribute{
-							Description: "The authentication key.",
+						"read": schema.SingleNestedAttribute{
+							Description: "Read access settings.",
 							Optional:    true,
-							Sensitive:   true,
+							Attributes: map[string]schema.Attribute{
+								"group_ids": schema.ListAttribute{
+									Description: "List of group IDs with read access.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+								"user_ids": schema.ListAttribute{
+									Description: "List of user IDs with read access.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+							},
 						},
-						"config": schema.MapAttribute{
-							Description: "Additional configuration for the tool server.",
+						"write": schema.SingleNestedAttribute{
+							Description: "Write access settings.",
 							Optional:    true,
-							ElementType: types.StringType,
+							Attributes: map[string]schema.Attribute{
+								"group_ids": schema.ListAttribute{
+									Description: "List of group IDs with write access.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+								"user_ids": schema.ListAttribute{
+									Description: "List of user IDs with write access.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+							},
 						},
 					},
 				},
+				"function_name_filter_list": schema.StringAttribute{
+					Description: "Comma-separated list of tool function names to expose. All functions are exposed when unset.",
+					Optional:    true,
+				},
+			},
+		},
+		"info": schema.SingleNestedAttribute{
+			Description: "Identity of the tool server connection.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "The ID of the tool server. For 'oauth_2.1' connections this must match the `client_id` of the `openwebui_oauth_client`.",
+					Optional:    true,
+				},
+				"name": schema.StringAttribute{
+					Description: "The display name of the tool server.",
+					Optional:    true,
+				},
+				"description": schema.StringAttribute{
+					Description: "Description of the tool server.",
+					Optional:    true,
+				},
+				"oauth_client_info": schema.StringAttribute{
+					Description: "The encrypted OAuth client registration, typically `openwebui_oauth_client.<name>.oauth_client_info`.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+			},
+		},
+		"spec_type": schema.StringAttribute{
+			Description: "Where the OpenAPI spec is loaded from. If set, must be one of: 'url', 'json'.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("url", "json"),
 			},
 		},
+		"spec": schema.StringAttribute{
+			Description: "The OpenAPI spec as JSON, used when spec_type is 'json'.",
+			Optional:    true,
+		},
+		"verify": schema.BoolAttribute{
+			Description: "Whether to verify the tool server URL and credentials with OpenWebUI on every plan. Verification failures are reported against this connection.",
+			Optional:    true,
+		},
 	}
 }
 
@@ -124,29 +219,7 @@ func (r *ToolServersConfigResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	for i, conn := range plan.ToolServerConnections {
-		apiConn := configs.APIToolServerConnection{
-			URL:  conn.URL.ValueString(),
-			Path: conn.Path.ValueString(),
-		}
-		if !conn.Type.IsNull() {
-			apiConn.Type = conn.Type.ValueString()
-		}
-		if !conn.AuthType.IsNull() {
-			apiConn.AuthType = conn.AuthType.ValueString()
-		}
-		if !conn.Key.IsNull() {
-			apiConn.Key = conn.Key.ValueString()
-		}
-		if !conn.Config.IsNull() {
-			configMap := make(map[string]interface{})
-			diags := conn.Config.ElementsAs(ctx, &configMap, false)
-			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-			apiConn.Config = configMap
-		}
-		apiConfig.ToolServerConnections[i] = apiConn
+		apiConfig.ToolServerConnections[i] = toolServerConnectionToAPI(&conn)
 	}
 
 	config, err := r.client.UpdateToolServers(apiConfig)
@@ -157,6 +230,7 @@ func (r *ToolServersConfigResource) Create(ctx context.Context, req resource.Cre
 
 	// Convert API response back to Terraform model
 	state := configs.APIToToolServersConfig(config)
+	preserveToolServerVerify(plan.ToolServerConnections, state)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -178,6 +252,7 @@ func (r *ToolServersConfigResource) Read(ctx context.Context, req resource.ReadR
 
 	// Convert API response to Terraform model
 	newState := configs.APIToToolServersConfig(config)
+	preserveToolServerVerify(state.ToolServerConnections, newState)
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -197,29 +272,7 @@ func (r *ToolServersConfigResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	for i, conn := range plan.ToolServerConnections {
-		apiConn := configs.APIToolServerConnection{
-			URL:  conn.URL.ValueString(),
-			Path: conn.Path.ValueString(),
-		}
-		if !conn.Type.IsNull() {
-			apiConn.Type = conn.Type.ValueString()
-		}
-		if !conn.AuthType.IsNull() {
-			apiConn.AuthType = conn.AuthType.ValueString()
-		}
-		if !conn.Key.IsNull() {
-			apiConn.Key = conn.Key.ValueString()
-		}
-		if !conn.Config.IsNull() {
-			configMap := make(map[string]interface{})
-			diags := conn.Config.ElementsAs(ctx, &configMap, false)
-			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-			apiConn.Config = configMap
-		}
-		apiConfig.ToolServerConnections[i] = apiConn
+		apiConfig.ToolServerConnections[i] = toolServerConnectionToAPI(&conn)
 	}
 
 	config, err := r.client.UpdateToolServers(apiConfig)
@@ -230,6 +283,7 @@ func (r *ToolServersConfigResource) Update(ctx context.Context, req resource.Upd
 
 	// Convert API response back to Terraform model
 	state := configs.APIToToolServersConfig(config)
+	preserveToolServerVerify(plan.ToolServerConnections, state)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -248,6 +302,42 @@ func (r *ToolServersConfigResource) Delete(ctx context.Context, req resource.Del
 	}
 }
 
+func (r *ToolServersConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to verify on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var connections types.List
+	diags := req.Plan.GetAttribute(ctx, path.Root("tool_server_connections"), &connections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || connections.IsUnknown() {
+		return
+	}
+
+	var plan configs.ToolServersConfig
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, conn := range plan.ToolServerConnections {
+		if !conn.Verify.ValueBool() || !toolServerConnectionIsKnown(&conn) {
+			continue
+		}
+
+		apiConn := toolServerConnectionToAPI(&conn)
+		if err := r.client.VerifyToolServer(&apiConn); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tool_server_connections").AtListIndex(i),
+				"Tool Server Verification Failed",
+				fmt.Sprintf("OpenWebUI could not verify the tool server at %s: %s", apiConn.URL, err),
+			)
+		}
+	}
+}
+
 func (r *ToolServersConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// The import ID must be "tool_servers"
 	if req.ID != "tool_servers" {
@@ -259,3 +349,99 @@ func (r *ToolServersConfigResource) ImportState(ctx context.Context, req resourc
 	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// toolServerConnectionToAPI converts a Terraform tool server connection to the API model.
+func toolServerConnectionToAPI(conn *configs.ToolServerConnection) configs.APIToolServerConnection {
+	apiConn := configs.APIToolServerConnection{
+		URL:      conn.URL.ValueString(),
+		Path:     conn.Path.ValueString(),
+		AuthType: conn.AuthType.ValueStringPointer(),
+		Key:      conn.Key.ValueStringPointer(),
+		SpecType: conn.SpecType.ValueStringPointer(),
+		Spec:     conn.Spec.ValueStringPointer(),
+	}
+	if !conn.Type.IsNull() {
+		apiConn.Type = conn.Type.ValueString()
+	}
+	if conn.Config != nil {
+		apiConn.Config = &configs.APIToolServerConnectionConfig{
+			Enable:                 conn.Config.Enable.ValueBoolPointer(),
+			AccessControl:          models.AccessControlToAPI(conn.Config.AccessControl),
+			FunctionNameFilterList: conn.Config.FunctionNameFilterList.ValueStringPointer(),
+		}
+	}
+	if conn.Info != nil {
+		apiConn.Info = &configs.APIToolServerInfo{
+			ID:              conn.Info.ID.ValueString(),
+			Name:            conn.Info.Name.ValueString(),
+			Description:     conn.Info.Description.ValueString(),
+			OAuthClientInfo: conn.Info.OAuthClientInfo.ValueString(),
+		}
+	}
+
+	return apiConn
+}
+
+// toolServerConnectionIsKnown reports whether every value sent to the API is
+// known, so the connection can be verified at plan time.
+func toolServerConnectionIsKnown(conn *configs.ToolServerConnection) bool {
+	if conn.URL.IsUnknown() || conn.Path.IsUnknown() || conn.Type.IsUnknown() ||
+		conn.AuthType.IsUnknown() || conn.Key.IsUnknown() ||
+		conn.SpecType.IsUnknown() || conn.Spec.IsUnknown() {
+		return false
+	}
+	if conn.Config != nil {
+		if conn.Config.Enable.IsUnknown() || conn.Config.FunctionNameFilterList.IsUnknown() ||
+			!accessControlIsKnown(conn.Config.AccessControl) {
+			return false
+		}
+	}
+	if conn.Info != nil {
+		if conn.Info.ID.IsUnknown() || conn.Info.Name.IsUnknown() ||
+			conn.Info.Description.IsUnknown() || conn.Info.OAuthClientInfo.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// preserveToolServerVerify carries the provider-only verify flag over from the
+// prior plan or state, since the API does not store it. Connections are matched
+// by URL and path.
+func preserveToolServerVerify(prior []configs.ToolServerConnection, state *configs.ToolServersConfig) {
+	verify := make(map[string]types.Bool, len(prior))
+	for _, conn := range prior {
+		verify[toolServerConnectionKey(conn.URL.ValueString(), conn.Path.ValueString())] = conn.Verify
+	}
+
+	for i, conn := range state.ToolServerConnections {
+		if value, ok := verify[toolServerConnectionKey(conn.URL.ValueString(), conn.Path.ValueString())]; ok {
+			state.ToolServerConnections[i].Verify = value
+		}
+	}
+}
+
+// toolServerConnectionKey identifies a tool server connection by URL and path.
+func toolServerConnectionKey(url, path string) string {
+	return url + "\x00" + path
+}
+
+// accessControlIsKnown reports whether every group and user ID is known.
+func accessControlIsKnown(accessControl *models.AccessControl) bool {
+	if accessControl == nil {
+		return true
+	}
+	for _, group := range []*models.AccessGroup{accessControl.Read, accessControl.Write} {
+		if group == nil {
+			continue
+		}
+		for _, ids := range [][]types.String{group.GroupIDs, group.UserIDs} {
+			for _, id := range ids {
+				if id.IsUnknown() {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,539 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-openwebui/internal/provider/client/functions"
+	"terraform-provider-openwebui/internal/provider/client/pipelines"
+	"terraform-provider-openwebui/internal/provider/client/tools"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ValvesResource{}
+var _ resource.ResourceWithModifyPlan = &ValvesResource{}
+var _ resource.ResourceWithImportState = &ValvesResource{}
+
+// valvesClient is implemented by the functions and tools clients, and by
+// pipelineValvesClient
+type valvesClient interface {
+	GetValves(id string) (map[string]interface{}, error)
+	GetValvesSpec(id string) (map[string]interface{}, error)
+	UpdateValves(id string, values map[string]interface{}) (map[string]interface{}, error)
+}
+
+func NewFunctionValvesResource() resource.Resource {
+	return &ValvesResource{kind: "function"}
+}
+
+func NewToolValvesResource() resource.Resource {
+	return &ValvesResource{kind: "tool"}
+}
+
+func NewPipelineValvesResource() resource.Resource {
+	return &ValvesResource{kind: "pipeline"}
+}
+
+// ValvesResource defines the resource implementation shared by function, tool
+// and pipeline valves. The owner is referenced by the `<kind>_id` attribute.
+type ValvesResource struct {
+	kind   string
+	client valvesClient
+}
+
+// ValvesResourceModel describes the resource data model, except for the
+// owner ID whose attribute name depends on the kind.
+type ValvesResourceModel struct {
+	ID              types.String
+	OwnerID         types.String
+	Values          types.Map
+	SensitiveValues types.Map
+}
+
+// attributeGetter is satisfied by plans, states and configs
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+func (r *ValvesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind + "_valves"
+}
+
+func (r *ValvesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ownerDescription := fmt.Sprintf("ID of the %s", r.kind)
+	if r.kind == "pipeline" {
+		ownerDescription = "ID of the `openwebui_pipeline` resource, `<url_idx>/<pipeline_id>`"
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages the admin valves of an OpenWebUI %s. ", r.kind) +
+			"Values are validated against the valves spec of the " + r.kind + " and converted to the declared types. " +
+			"Valves not set here keep their current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Identifier of the valves, the %s ID", r.kind),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			r.kind + "_id": schema.StringAttribute{
+				MarkdownDescription: ownerDescription,
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Valve values by name. Lists and objects are given as JSON",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"sensitive_values": schema.MapAttribute{
+				MarkdownDescription: "Secret valve values by name, e.g. API keys",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *ValvesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	switch r.kind {
+	case "function":
+		client, ok := clients["functions"].(*functions.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *functions.Client, got: %T. Please report this issue to the provider developers.", clients["functions"]),
+			)
+			return
+		}
+		r.client = client
+	case "tool":
+		client, ok := clients["tools"].(*tools.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *tools.Client, got: %T. Please report this issue to the provider developers.", clients["tools"]),
+			)
+			return
+		}
+		r.client = client
+	case "pipeline":
+		client, ok := clients["pipelines"].(*pipelines.Client)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected *pipelines.Client, got: %T. Please report this issue to the provider developers.", clients["pipelines"]),
+			)
+			return
+		}
+		r.client = pipelineValvesClient{client: client}
+	}
+}
+
+func (r *ValvesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan, diags := r.getModel(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.OwnerID.IsUnknown() || plan.Values.IsUnknown() || plan.SensitiveValues.IsUnknown() {
+		return
+	}
+
+	// The owner may be created in the same apply; validation then happens on apply
+	spec, err := r.client.GetValvesSpec(plan.OwnerID.ValueString())
+	if isValvesOwnerNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s valves spec, got error: %s", r.kind, err))
+		return
+	}
+
+	values, sensitiveValues, diags := valvesMaps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags = buildValves(spec, values, sensitiveValues)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ValvesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	data, diags := r.getModel(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, ValvesResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = data.OwnerID
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+}
+
+func (r *ValvesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	data, diags := r.getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetValves(data.OwnerID.ValueString())
+	if isValvesOwnerNotFound(err) {
+		// The owner was deleted outside of Terraform, and its valves with it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s valves, got error: %s", r.kind, err))
+		return
+	}
+
+	// Refresh the managed valves so that changes made outside of Terraform show up
+	data.Values, diags = refreshValves(ctx, data.Values, current)
+	resp.Diagnostics.Append(diags...)
+	data.SensitiveValues, diags = refreshValves(ctx, data.SensitiveValues, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+}
+
+func (r *ValvesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan and prior state data into the models
+	data, diags := r.getModel(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	state, diags := r.getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = data.OwnerID
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+}
+
+func (r *ValvesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	data, diags := r.getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to reset if the owner is already gone
+	if _, err := r.client.GetValves(data.OwnerID.ValueString()); isValvesOwnerNotFound(err) {
+		return
+	}
+
+	// Reset the managed valves to their defaults
+	resp.Diagnostics.Append(r.apply(ctx, ValvesResourceModel{
+		OwnerID:         data.OwnerID,
+		Values:          types.MapNull(types.StringType),
+		SensitiveValues: types.MapNull(types.StringType),
+	}, data)...)
+}
+
+func (r *ValvesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(r.kind+"_id"), req.ID)...)
+}
+
+// apply writes the planned valves, keeping valves that are not managed by
+// this resource and removing those that were managed before but no longer are.
+func (r *ValvesResource) apply(ctx context.Context, plan, prior ValvesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ownerID := plan.OwnerID.ValueString()
+
+	spec, err := r.client.GetValvesSpec(ownerID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read %s valves spec, got error: %s", r.kind, err))
+		return diags
+	}
+	current, err := r.client.GetValves(ownerID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read %s valves, got error: %s", r.kind, err))
+		return diags
+	}
+
+	values, sensitiveValues, d := valvesMaps(ctx, plan)
+	diags.Append(d...)
+	priorValues, priorSensitiveValues, d := valvesMaps(ctx, prior)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	planned, d := buildValves(spec, values, sensitiveValues)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	merged := make(map[string]interface{}, len(current)+len(planned))
+	for name, value := range current {
+		_, managed := priorValues[name]
+		_, managedSensitive := priorSensitiveValues[name]
+		if !managed && !managedSensitive {
+			merged[name] = value
+		}
+	}
+	for name, value := range planned {
+		merged[name] = value
+	}
+
+	if _, err := r.client.UpdateValves(ownerID, merged); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update %s valves, got error: %s", r.kind, err))
+	}
+	return diags
+}
+
+func (r *ValvesResource) getModel(ctx context.Context, source attributeGetter) (ValvesResourceModel, diag.Diagnostics) {
+	var data ValvesResourceModel
+	var diags diag.Diagnostics
+	diags.Append(source.GetAttribute(ctx, path.Root("id"), &data.ID)...)
+	diags.Append(source.GetAttribute(ctx, path.Root(r.kind+"_id"), &data.OwnerID)...)
+	diags.Append(source.GetAttribute(ctx, path.Root("values"), &data.Values)...)
+	diags.Append(source.GetAttribute(ctx, path.Root("sensitive_values"), &data.SensitiveValues)...)
+	return data, diags
+}
+
+func (r *ValvesResource) setModel(ctx context.Context, state interface {
+	SetAttribute(ctx context.Context, path path.Path, val interface{}) diag.Diagnostics
+}, data ValvesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root(r.kind+"_id"), data.OwnerID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("values"), data.Values)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("sensitive_values"), data.SensitiveValues)...)
+	return diags
+}
+
+// valvesMaps returns the configured values and sensitive values as Go maps.
+func valvesMaps(ctx context.Context, data ValvesResourceModel) (map[string]string, map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make(map[string]string)
+	sensitiveValues := make(map[string]string)
+	if !data.Values.IsNull() && !data.Values.IsUnknown() {
+		diags.Append(data.Values.ElementsAs(ctx, &values, false)...)
+	}
+	if !data.SensitiveValues.IsNull() && !data.SensitiveValues.IsUnknown() {
+		diags.Append(data.SensitiveValues.ElementsAs(ctx, &sensitiveValues, false)...)
+	}
+	return values, sensitiveValues, diags
+}
+
+// refreshValves updates the managed entries of a values map from the current
+// valves. Configured strings that denote the current value, e.g. "1.50" for
+// 1.5 or JSON with other whitespace, are kept as they are.
+func refreshValves(ctx context.Context, managed types.Map, current map[string]interface{}) (types.Map, diag.Diagnostics) {
+	if managed.IsNull() || managed.IsUnknown() {
+		return managed, nil
+	}
+
+	refreshed := make(map[string]string, len(managed.Elements()))
+	for name, element := range managed.Elements() {
+		configured, _ := element.(types.String)
+		if valveValueEqual(configured.ValueString(), current[name]) {
+			refreshed[name] = configured.ValueString()
+		} else {
+			refreshed[name] = formatValveValue(current[name])
+		}
+	}
+	return types.MapValueFrom(ctx, types.StringType, refreshed)
+}
+
+// valveValueEqual reports whether a configured string denotes a valve value
+// returned by the API.
+func valveValueEqual(configured string, value interface{}) bool {
+	if configured == formatValveValue(value) {
+		return true
+	}
+
+	switch v := value.(type) {
+	case bool:
+		parsed, err := strconv.ParseBool(configured)
+		return err == nil && parsed == v
+	case float64:
+		parsed, err := strconv.ParseFloat(configured, 64)
+		return err == nil && parsed == v
+	case []interface{}, map[string]interface{}:
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(configured), &parsed); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(parsed, v)
+	default:
+		return false
+	}
+}
+
+// isValvesOwnerNotFound reports whether err means that the function, tool or
+// pipeline owning the valves does not exist.
+func isValvesOwnerNotFound(err error) bool {
+	return errors.Is(err, functions.ErrNotFound) || errors.Is(err, tools.ErrNotFound) || errors.Is(err, pipelines.ErrNotFound)
+}
+
+// buildValves validates configured valve values against the valves JSON
+// schema of a function or tool and converts them to the declared types.
+func buildValves(spec map[string]interface{}, values, sensitiveValues map[string]string) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	properties, _ := spec["properties"].(map[string]interface{})
+
+	result := make(map[string]interface{}, len(values)+len(sensitiveValues))
+	for _, group := range []struct {
+		attribute string
+		values    map[string]string
+	}{
+		{"values", values},
+		{"sensitive_values", sensitiveValues},
+	} {
+		names := make([]string, 0, len(group.values))
+		for name := range group.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := properties[name].(map[string]interface{})
+			if !ok {
+				diags.AddAttributeError(path.Root(group.attribute), "Unknown Valve", fmt.Sprintf("Valve %q is not declared in the valves spec.", name))
+				continue
+			}
+			if _, ok := result[name]; ok {
+				diags.AddAttributeError(path.Root(group.attribute), "Duplicate Valve", fmt.Sprintf("Valve %q is set in both values and sensitive_values.", name))
+				continue
+			}
+
+			value, err := parseValveValue(property, group.values[name])
+			if err != nil {
+				diags.AddAttributeError(path.Root(group.attribute), "Invalid Valve Value", fmt.Sprintf("Valve %q: %s", name, err))
+				continue
+			}
+			result[name] = value
+		}
+	}
+
+	return result, diags
+}
+
+// valveType returns the JSON schema type of a valve, looking through optional
+// types declared as anyOf with null.
+func valveType(property map[string]interface{}) string {
+	if valueType, ok := property["type"].(string); ok {
+		return valueType
+	}
+	if anyOf, ok := property["anyOf"].([]interface{}); ok {
+		for _, option := range anyOf {
+			if option, ok := option.(map[string]interface{}); ok {
+				if valueType, ok := option["type"].(string); ok && valueType != "null" {
+					return valueType
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// parseValveValue converts a configured string to the type declared for the valve.
+func parseValveValue(property map[string]interface{}, value string) (interface{}, error) {
+	switch valveType(property) {
+	case "integer":
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", value)
+		}
+		return parsed, nil
+	case "number":
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", value)
+		}
+		return parsed, nil
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got %q", value)
+		}
+		return parsed, nil
+	case "array", "object":
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, fmt.Errorf("expected JSON, got %q", value)
+		}
+		return parsed, nil
+	default:
+		return value, nil
+	}
+}
+
+// formatValveValue converts a valve value returned by the API to its
+// configured string form.
+func formatValveValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
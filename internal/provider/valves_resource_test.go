@@ -0,0 +1,204 @@
+// Copyright (c) Coalition, Inc
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-openwebui/internal/provider/client/functions"
+	"terraform-provider-openwebui/internal/provider/client/pipelines"
+)
+
+const testValvesSpec = `{
+	"properties": {
+		"api_key": {"type": "string"},
+		"max_results": {"type": "integer"},
+		"threshold": {"type": "number"},
+		"enabled": {"type": "boolean"},
+		"models": {"type": "array", "items": {"type": "string"}},
+		"priority": {"anyOf": [{"type": "null"}, {"type": "integer"}]}
+	}
+}`
+
+func TestBuildValves(t *testing.T) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(testValvesSpec), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	values, diags := buildValves(spec, map[string]string{
+		"max_results": "5",
+		"threshold":   "0.25",
+		"enabled":     "true",
+		"models":      `["a","b"]`,
+		"priority":    "3",
+	}, map[string]string{
+		"api_key": "secret",
+	})
+	if diags.HasError() {
+		t.Fatalf("buildValves returned errors: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"api_key":     "secret",
+		"max_results": int64(5),
+		"threshold":   0.25,
+		"enabled":     true,
+		"models":      []interface{}{"a", "b"},
+		"priority":    int64(3),
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestBuildValvesErrors(t *testing.T) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(testValvesSpec), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		values    map[string]string
+		sensitive map[string]string
+	}{
+		"unknown valve":  {values: map[string]string{"unknown": "x"}},
+		"invalid int":    {values: map[string]string{"max_results": "five"}},
+		"invalid bool":   {values: map[string]string{"enabled": "maybe"}},
+		"invalid json":   {values: map[string]string{"models": "a,b"}},
+		"set in both":    {values: map[string]string{"api_key": "a"}, sensitive: map[string]string{"api_key": "b"}},
+		"no valves spec": {sensitive: map[string]string{"api_key": "a"}},
+	}
+
+	for name, c := range cases {
+		s := spec
+		if name == "no valves spec" {
+			s = nil
+		}
+		if _, diags := buildValves(s, c.values, c.sensitive); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFormatValveValue(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{true, "true"},
+		{float64(5), "5"},
+		{0.25, "0.25"},
+		{[]interface{}{"a", "b"}, `["a","b"]`},
+	}
+
+	for _, c := range cases {
+		if got := formatValveValue(c.value); got != c.want {
+			t.Errorf("formatValveValue(%v) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestRefreshValvesKeepsEquivalentValues(t *testing.T) {
+	managed, _ := types.MapValueFrom(context.Background(), types.StringType, map[string]string{
+		"threshold": "1.50",
+		"enabled":   "True",
+		"models":    `[ "a", "b" ]`,
+		"options":   `{"b": 2, "a": 1}`,
+		"name":      "old",
+	})
+	current := map[string]interface{}{
+		"threshold": 1.5,
+		"enabled":   true,
+		"models":    []interface{}{"a", "b"},
+		"options":   map[string]interface{}{"a": 1.0, "b": 2.0},
+		"name":      "new",
+	}
+
+	refreshed, diags := refreshValves(context.Background(), managed, current)
+	if diags.HasError() {
+		t.Fatalf("refreshValves returned error: %v", diags)
+	}
+
+	var values map[string]string
+	refreshed.ElementsAs(context.Background(), &values, false)
+	expected := map[string]string{
+		"threshold": "1.50",
+		"enabled":   "True",
+		"models":    `[ "a", "b" ]`,
+		"options":   `{"b": 2, "a": 1}`,
+		"name":      "new",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestValvesOwnerNotFound(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status == http.StatusUnauthorized {
+			w.Write([]byte(`{"detail":"We could not find what you're looking for :/"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := functions.NewClient(server.URL, "token")
+	if _, err := client.GetValvesSpec("missing"); !isValvesOwnerNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	status = http.StatusInternalServerError
+	if _, err := client.GetValvesSpec("broken"); err == nil || isValvesOwnerNotFound(err) {
+		t.Errorf("Expected a server error, got %v", err)
+	}
+}
+
+func TestValvesOwnerDeleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &ValvesResource{kind: "pipeline", client: pipelineValvesClient{client: pipelines.NewClient(server.URL, "token")}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	values, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"threshold": "0.5"})
+	r.setModel(ctx, &state, ValvesResourceModel{
+		ID:              types.StringValue("0/scraper"),
+		OwnerID:         types.StringValue("0/scraper"),
+		Values:          values,
+		SensitiveValues: types.MapNull(types.StringType),
+	})
+
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("Expected the valves to be removed from state, got %v", readResp.Diagnostics)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Expected deleting valves of a deleted pipeline to succeed, got %v", deleteResp.Diagnostics)
+	}
+}